@@ -1,21 +1,42 @@
 package server
 
 import (
+	"bytes"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	gameService "github.com/matiasvarela/minesweeper-API/internal/core/service/game"
+	matchService "github.com/matiasvarela/minesweeper-API/internal/core/service/match"
+	puzzleService "github.com/matiasvarela/minesweeper-API/internal/core/service/puzzle"
+	spectatorService "github.com/matiasvarela/minesweeper-API/internal/core/service/spectator"
 	"github.com/matiasvarela/minesweeper-API/internal/dep"
 	"github.com/matiasvarela/minesweeper-API/internal/handler"
+	gameNotifier "github.com/matiasvarela/minesweeper-API/internal/notifier/game"
+	matchNotifier "github.com/matiasvarela/minesweeper-API/internal/notifier/match"
 	gameRepo "github.com/matiasvarela/minesweeper-API/internal/repository/game"
+	matchRepo "github.com/matiasvarela/minesweeper-API/internal/repository/match"
+	puzzleRepo "github.com/matiasvarela/minesweeper-API/internal/repository/puzzle"
+	shareLinkRepo "github.com/matiasvarela/minesweeper-API/internal/repository/sharelink"
+	"github.com/matiasvarela/minesweeper-API/pkg/auth"
 	"github.com/matiasvarela/minesweeper-API/pkg/clock"
 	"github.com/matiasvarela/minesweeper-API/pkg/random"
+	"io/ioutil"
 	"os"
+	"time"
 )
 
 const (
-	dynamoDBGamesTableName = "Games"
+	dynamoDBGamesTableName      = "Games"
+	dynamoDBPuzzlesTableName    = "Puzzles"
+	dynamoDBMatchesTableName    = "Matches"
+	dynamoDBShareLinksTableName = "ShareLinks"
+	gameSweeperInterval         = time.Minute
+	noGuessBudget               = 2 * time.Second
+	analysisBudget              = time.Second
+	hintBudget                  = time.Second
+	maxBoardRows                = 800
+	maxBoardColumns             = 800
 )
 
 func initDependencies() *dep.Dep {
@@ -33,12 +54,85 @@ func initDependencies() *dep.Dep {
 	clk := clock.New()
 
 	d.GameRepository = gameRepo.NewDynamoDB(dynamoDBGamesTableName, d.DynamoDB)
-	d.GameService = gameService.NewService(rnd, clk, d.GameRepository)
-	d.GameHandler = handler.NewGameHandler(d.GameService)
+	d.PuzzleRepository = puzzleRepo.NewDynamoDB(dynamoDBPuzzlesTableName, d.DynamoDB)
+	d.MatchRepository = matchRepo.NewDynamoDB(dynamoDBMatchesTableName, d.DynamoDB)
+	d.ShareLinkRepository = shareLinkRepo.NewDynamoDB(dynamoDBShareLinksTableName, d.DynamoDB)
+	d.MatchNotifier = matchNotifier.NewMemory()
+	d.GameNotifier = gameNotifier.NewMemory()
+	d.GameService = gameService.NewService(rnd, clk, d.GameRepository, d.PuzzleRepository, d.MatchRepository, d.MatchNotifier, d.GameNotifier,
+		gameService.WithNoGuessBudget(noGuessBudget),
+		gameService.WithAnalysisBudget(analysisBudget),
+		gameService.WithHintBudget(hintBudget),
+		gameService.WithMaxBoardSize(maxBoardRows, maxBoardColumns),
+		gameService.WithMaxEncodedSize(gameRepo.MaxEncodedSize),
+	)
+	d.GameHandler = handler.NewGameHandler(d.GameService, clk)
+	d.GameSweeper = gameService.NewSweeper(d.GameService, gameSweeperInterval)
+	d.PuzzleService = puzzleService.NewService(rnd, clk, d.PuzzleRepository, d.GameRepository,
+		puzzleService.WithMaxBoardSize(maxBoardRows, maxBoardColumns),
+	)
+	d.PuzzleHandler = handler.NewPuzzleHandler(d.PuzzleService)
+	d.MatchService = matchService.NewService(rnd, clk, d.MatchRepository, d.GameService, d.MatchNotifier)
+	d.MatchHandler = handler.NewMatchHandler(d.MatchService)
+	d.SpectatorService = spectatorService.NewService(rnd, clk, d.ShareLinkRepository, d.GameService, d.GameNotifier)
+	d.SpectatorHandler = handler.NewSpectatorHandler(d.SpectatorService)
+	d.AuthHandler = handler.NewAuthHandler(newVerifier(clk))
 
 	return d
 }
 
+// newVerifier accepts the tokens signed with the keys given by the environment: the HS256 secret, directly or in a file,
+// and the PEM encoded RS256 public key in a file. At least one of them is required
+func newVerifier(clk clock.Clock) auth.Verifier {
+	var options []auth.Option
+
+	secret := []byte(os.Getenv("AUTH_HS256_SECRET"))
+	if path := os.Getenv("AUTH_HS256_SECRET_FILE"); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+
+		secret = bytes.TrimSpace(data)
+	}
+
+	if len(secret) > 0 {
+		options = append(options, auth.WithHMACSecret(secret))
+	}
+
+	if path := os.Getenv("AUTH_RS256_PUBLIC_KEY_FILE"); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+
+		key, err := auth.ParseRSAPublicKey(data)
+		if err != nil {
+			panic(err)
+		}
+
+		options = append(options, auth.WithRSAPublicKey(key))
+	}
+
+	if len(options) == 0 {
+		panic("no key has been given to verify the tokens, set AUTH_HS256_SECRET, AUTH_HS256_SECRET_FILE or AUTH_RS256_PUBLIC_KEY_FILE")
+	}
+
+	if claim := os.Getenv("AUTH_USER_ID_CLAIM"); claim != "" {
+		options = append(options, auth.WithUserIDClaim(claim))
+	}
+
+	if issuer := os.Getenv("AUTH_ISSUER"); issuer != "" {
+		options = append(options, auth.WithIssuer(issuer))
+	}
+
+	if audience := os.Getenv("AUTH_AUDIENCE"); audience != "" {
+		options = append(options, auth.WithAudience(audience))
+	}
+
+	return auth.NewVerifier(clk, options...)
+}
+
 func newProdDynamoDB() *dynamodb.DynamoDB {
 	config := &aws.Config{
 		Region:      aws.String("us-east-2"),
@@ -60,7 +154,7 @@ func newLocalDynamoDB() *dynamodb.DynamoDB {
 
 	svc := dynamodb.New(sess)
 
-	input := &dynamodb.CreateTableInput{
+	createTable(svc, &dynamodb.CreateTableInput{
 		AttributeDefinitions: []*dynamodb.AttributeDefinition{
 			{
 				AttributeName: aws.String("id"),
@@ -70,6 +164,18 @@ func newLocalDynamoDB() *dynamodb.DynamoDB {
 				AttributeName: aws.String("user_id"),
 				AttributeType: aws.String("S"),
 			},
+			{
+				AttributeName: aws.String("puzzle_id"),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String("state"),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String("expires_at"),
+				AttributeType: aws.String("N"),
+			},
 		},
 		KeySchema: []*dynamodb.KeySchemaElement{
 			{
@@ -81,21 +187,137 @@ func newLocalDynamoDB() *dynamodb.DynamoDB {
 				KeyType:       aws.String("RANGE"),
 			},
 		},
+		GlobalSecondaryIndexes: []*dynamodb.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String(gameRepo.IDIndexName),
+				KeySchema: []*dynamodb.KeySchemaElement{
+					{
+						AttributeName: aws.String("id"),
+						KeyType:       aws.String("HASH"),
+					},
+				},
+				Projection: &dynamodb.Projection{
+					ProjectionType: aws.String(dynamodb.ProjectionTypeKeysOnly),
+				},
+				ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(10),
+					WriteCapacityUnits: aws.Int64(10),
+				},
+			},
+			{
+				IndexName: aws.String(gameRepo.PuzzleIndexName),
+				KeySchema: []*dynamodb.KeySchemaElement{
+					{
+						AttributeName: aws.String("puzzle_id"),
+						KeyType:       aws.String("HASH"),
+					},
+				},
+				Projection: &dynamodb.Projection{
+					ProjectionType: aws.String(dynamodb.ProjectionTypeAll),
+				},
+				ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(10),
+					WriteCapacityUnits: aws.Int64(10),
+				},
+			},
+			{
+				IndexName: aws.String(gameRepo.ExpiryIndexName),
+				KeySchema: []*dynamodb.KeySchemaElement{
+					{
+						AttributeName: aws.String("state"),
+						KeyType:       aws.String("HASH"),
+					},
+					{
+						AttributeName: aws.String("expires_at"),
+						KeyType:       aws.String("RANGE"),
+					},
+				},
+				Projection: &dynamodb.Projection{
+					ProjectionType: aws.String(dynamodb.ProjectionTypeAll),
+				},
+				ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(10),
+					WriteCapacityUnits: aws.Int64(10),
+				},
+			},
+		},
 		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
 			ReadCapacityUnits:  aws.Int64(10),
 			WriteCapacityUnits: aws.Int64(10),
 		},
 		TableName: aws.String(dynamoDBGamesTableName),
-	}
+	})
 
+	createTable(svc, &dynamodb.CreateTableInput{
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String("id"),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String("id"),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(dynamoDBPuzzlesTableName),
+	})
+
+	createTable(svc, &dynamodb.CreateTableInput{
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String("id"),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String("id"),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(dynamoDBMatchesTableName),
+	})
+
+	createTable(svc, &dynamodb.CreateTableInput{
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String("token"),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String("token"),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(dynamoDBShareLinksTableName),
+	})
+
+	return svc
+}
+
+// createTable creates the given table from scratch, deleting it first if it already exists
+func createTable(svc *dynamodb.DynamoDB, input *dynamodb.CreateTableInput) {
 	svc.DeleteTable(&dynamodb.DeleteTableInput{
-		TableName: aws.String(dynamoDBGamesTableName),
+		TableName: input.TableName,
 	})
 
-	_, err := svc.CreateTable(input)
-	if err != nil {
+	if _, err := svc.CreateTable(input); err != nil {
 		panic(err)
 	}
-
-	return svc
 }
@@ -11,9 +11,34 @@ func routes(router *gin.Engine, dependencies *dep.Dep) {
 		request.String(http.StatusOK, "pong")
 	})
 
-	router.POST("/users/:user_id/games", dependencies.GameHandler.Create)
-	router.GET("/users/:user_id/games", dependencies.GameHandler.GetAll)
-	router.GET("/users/:user_id/games/:game_id", dependencies.GameHandler.Get)
-	router.PUT("/users/:user_id/games/:game_id/actions/reveal", dependencies.GameHandler.Reveal)
-	router.PUT("/users/:user_id/games/:game_id/actions/mark", dependencies.GameHandler.Mark)
+	users := router.Group("/users/:user_id", dependencies.AuthHandler.Authenticate)
+	users.POST("/games", dependencies.GameHandler.Create)
+	users.GET("/games", dependencies.GameHandler.GetAll)
+	users.GET("/games/:game_id", dependencies.GameHandler.Get)
+	users.GET("/games/:game_id/image.svg", dependencies.GameHandler.ImageSVG)
+	users.GET("/games/:game_id/image.png", dependencies.GameHandler.ImagePNG)
+	users.GET("/games/:game_id/analysis", dependencies.GameHandler.Analyze)
+	users.PUT("/games/:game_id/actions/reveal", dependencies.GameHandler.Reveal)
+	users.PUT("/games/:game_id/actions/mark", dependencies.GameHandler.Mark)
+	users.POST("/games/:game_id/actions", dependencies.GameHandler.Act)
+	users.POST("/games/:game_id/actions/hint", dependencies.GameHandler.Hint)
+	users.POST("/games/:game_id/actions/undo", dependencies.GameHandler.Undo)
+	users.POST("/games/:game_id/actions/retry", dependencies.GameHandler.Retry)
+	users.POST("/games/:game_id/participants", dependencies.GameHandler.AddParticipant)
+	users.DELETE("/games/:game_id/participants/:participant_id", dependencies.GameHandler.RemoveParticipant)
+	users.POST("/games/:game_id/share-links", dependencies.SpectatorHandler.Share)
+	users.DELETE("/games/:game_id/share-links/:token", dependencies.SpectatorHandler.Revoke)
+
+	authenticated := router.Group("", dependencies.AuthHandler.Authenticate)
+
+	authenticated.POST("/puzzles", dependencies.PuzzleHandler.Create)
+	router.GET("/puzzles/:puzzle_id", dependencies.PuzzleHandler.Get)
+	router.GET("/puzzles/:puzzle_id/leaderboard", dependencies.PuzzleHandler.Leaderboard)
+
+	authenticated.POST("/matches", dependencies.MatchHandler.Create)
+	authenticated.GET("/matches/:match_id", dependencies.MatchHandler.Get)
+	users.GET("/matches/:match_id/events", dependencies.MatchHandler.Events)
+
+	router.GET("/spectate/:token", dependencies.SpectatorHandler.Get)
+	router.GET("/spectate/:token/events", dependencies.SpectatorHandler.Events)
 }
@@ -6,6 +6,9 @@ func Start() {
 	dependencies := initDependencies()
 	router := gin.New()
 
+	dependencies.GameSweeper.Start()
+	defer dependencies.GameSweeper.Stop()
+
 	routes(router, dependencies)
 	run(router)
 }
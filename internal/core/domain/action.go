@@ -0,0 +1,25 @@
+package domain
+
+const (
+	ActionReveal = "reveal"
+	ActionMark   = "mark"
+	ActionChord  = "chord"
+)
+
+const (
+	ActionStatusApplied = "applied"
+	ActionStatusIgnored = "ignored"
+	ActionStatusSkipped = "skipped"
+)
+
+// Action is a move over a cell of the board, so several of them can be applied at once
+type Action struct {
+	Type string `json:"type"`
+	Coordinates
+}
+
+// ActionResult tells whether an action changed the game, did not change it or was not even applied because the game ended
+type ActionResult struct {
+	Action Action `json:"action"`
+	Status string `json:"status"`
+}
@@ -0,0 +1,550 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	AnalysisMethodExact      = "exact"
+	AnalysisMethodMonteCarlo = "monte_carlo"
+	AnalysisMethodEstimate   = "estimate"
+)
+
+// Analysis holds, for every covered cell, the probability of having a bomb given the information visible to the player
+type Analysis struct {
+	Method  string            `json:"method"`
+	Samples int               `json:"samples"`
+	Cells   []CellProbability `json:"cells"`
+}
+
+type CellProbability struct {
+	Position
+	Probability float64 `json:"probability"`
+}
+
+// problem is the set of covered cells of a board and the constraints the revealed cells impose over them
+type problem struct {
+	cells       []Position
+	constraints []constraint
+	indexes     [][]int // cell indexes of every constraint
+	touching    [][]int // constraint indexes of every cell
+	bombs       int     // bombs left among the cells
+	interior    []int   // cells not touching any constraint
+}
+
+// Analyze computes the probability of every covered cell having a bomb. It looks only at the revealed cells and the
+// total number of bombs. Probabilities are exact when all the layouts can be enumerated within the first half of the
+// budget; otherwise they are estimated sampling layouts at random with intn during the rest of the budget, and if not
+// even that is possible, they are approximated the same way hints estimate risks
+func Analyze(board Board, topology Topology, bombsNumber int, now func() time.Time, budget time.Duration, intn func(n int) int) Analysis {
+	solver := NewSolver(board, topology, bombsNumber)
+	p := newProblem(solver.unknown(), solver.constraints(), solver.RemainingBombs())
+
+	start := now()
+	expiredAt := func(deadline time.Time) func() bool {
+		return func() bool {
+			return !now().Before(deadline)
+		}
+	}
+
+	if probabilities, ok := p.exact(expiredAt(start.Add(budget / 2))); ok {
+		return p.analysis(AnalysisMethodExact, 0, probabilities)
+	}
+
+	if probabilities, samples, ok := p.monteCarlo(expiredAt(start.Add(budget)), intn); ok {
+		return p.analysis(AnalysisMethodMonteCarlo, samples, probabilities)
+	}
+
+	risks := solver.Risks()
+	probabilities := make([]float64, len(p.cells))
+	for i, pos := range p.cells {
+		probabilities[i] = risks[pos]
+	}
+
+	return p.analysis(AnalysisMethodEstimate, 0, probabilities)
+}
+
+func newProblem(cells []Position, constraints []constraint, bombs int) *problem {
+	p := &problem{cells: cells, constraints: constraints, bombs: bombs}
+
+	index := make(map[Position]int, len(cells))
+	for i, pos := range cells {
+		index[pos] = i
+	}
+
+	p.indexes = make([][]int, len(constraints))
+	p.touching = make([][]int, len(cells))
+	for i, c := range constraints {
+		for _, pos := range c.cells {
+			p.indexes[i] = append(p.indexes[i], index[pos])
+			p.touching[index[pos]] = append(p.touching[index[pos]], i)
+		}
+	}
+
+	for i := range cells {
+		if len(p.touching[i]) == 0 {
+			p.interior = append(p.interior, i)
+		}
+	}
+
+	return p
+}
+
+func (p *problem) analysis(method string, samples int, probabilities []float64) Analysis {
+	result := Analysis{Method: method, Samples: samples, Cells: make([]CellProbability, len(p.cells))}
+	for i, pos := range p.cells {
+		result.Cells[i] = CellProbability{Position: pos, Probability: probabilities[i]}
+	}
+
+	return result
+}
+
+// components groups the cells touching constraints so that two cells sharing a constraint are in the same group
+func (p *problem) components() [][]int {
+	parent := make([]int, len(p.cells))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	for _, cells := range p.indexes {
+		for _, cell := range cells[1:] {
+			parent[find(cell)] = find(cells[0])
+		}
+	}
+
+	groups := map[int][]int{}
+	var roots []int
+	for i := range p.cells {
+		if len(p.touching[i]) == 0 {
+			continue
+		}
+
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+
+		groups[root] = append(groups[root], i)
+	}
+
+	components := make([][]int, len(roots))
+	for i, root := range roots {
+		components[i] = groups[root]
+	}
+
+	return components
+}
+
+// enumeration holds, for every number of bombs k within a component, the share of its layouts having k bombs
+// and the share of those layouts having a bomb in every cell of the component
+type enumeration struct {
+	cells  []int
+	layout []float64
+	bombs  [][]float64
+}
+
+// exact enumerates the layouts of every component and combines them with the ways of placing the rest of the bombs
+// in the interior cells. Returns false if expired before finishing
+func (p *problem) exact(expired func() bool) ([]float64, bool) {
+	var enumerations []enumeration
+	for _, cells := range p.components() {
+		e, ok := p.enumerate(cells, expired)
+		if !ok {
+			return nil, false
+		}
+
+		enumerations = append(enumerations, e)
+	}
+
+	// prefix[i] and suffix[i] are the distributions of bombs among the components before i and from i onwards
+	prefix := make([][]float64, len(enumerations)+1)
+	suffix := make([][]float64, len(enumerations)+1)
+	prefix[0], suffix[len(enumerations)] = []float64{1}, []float64{1}
+	for i := range enumerations {
+		prefix[i+1] = convolve(prefix[i], enumerations[i].layout)
+		suffix[len(enumerations)-1-i] = convolve(enumerations[len(enumerations)-1-i].layout, suffix[len(enumerations)-i])
+	}
+
+	weight := p.interiorWeights(len(prefix[len(enumerations)]))
+	all := prefix[len(enumerations)]
+
+	total := 0.0
+	for t, value := range all {
+		total += value * weight(t)
+	}
+
+	if total == 0 {
+		return nil, false
+	}
+
+	probabilities := make([]float64, len(p.cells))
+	for i, e := range enumerations {
+		if expired() {
+			return nil, false
+		}
+
+		others := convolve(prefix[i], suffix[i+1])
+		for k := range e.layout {
+			factor := 0.0
+			for t, value := range others {
+				factor += value * weight(k+t)
+			}
+
+			for j, cell := range e.cells {
+				probabilities[cell] += e.bombs[k][j] * factor / total
+			}
+		}
+	}
+
+	if len(p.interior) > 0 {
+		expected := 0.0
+		for t, value := range all {
+			expected += value * weight(t) * float64(p.bombs-t)
+		}
+
+		for _, cell := range p.interior {
+			probabilities[cell] = expected / total / float64(len(p.interior))
+		}
+	}
+
+	return probabilities, true
+}
+
+// interiorWeights returns the number of ways of placing the bombs left in the interior cells when t bombs are in the
+// components, scaled so the biggest is 1
+func (p *problem) interiorWeights(size int) func(t int) float64 {
+	n := len(p.interior)
+	logs := make([]float64, size)
+	max := math.Inf(-1)
+
+	for t := range logs {
+		logs[t] = math.Inf(-1)
+		if k := p.bombs - t; k >= 0 && k <= n {
+			logs[t] = logCombinations(n, k)
+		}
+
+		max = math.Max(max, logs[t])
+	}
+
+	return func(t int) float64 {
+		if t >= size || math.IsInf(logs[t], -1) {
+			return 0
+		}
+
+		return math.Exp(logs[t] - max)
+	}
+}
+
+// enumerate walks all the layouts of bombs over the given cells satisfying the constraints they touch
+func (p *problem) enumerate(cells []int, expired func() bool) (enumeration, bool) {
+	e := enumeration{cells: cells, layout: make([]float64, len(cells)+1), bombs: make([][]float64, len(cells)+1)}
+	for k := range e.bombs {
+		e.bombs[k] = make([]float64, len(cells))
+	}
+
+	assigned := make([]int, len(p.constraints))
+	pending := make([]int, len(p.constraints))
+	for _, cell := range cells {
+		for _, c := range p.touching[cell] {
+			pending[c]++
+		}
+	}
+
+	layout := make([]bool, len(cells))
+	nodes := 0
+	aborted := false
+
+	var walk func(i int, bombs int)
+	walk = func(i int, bombs int) {
+		if aborted {
+			return
+		}
+
+		if nodes++; nodes%1024 == 0 && expired() {
+			aborted = true
+			return
+		}
+
+		if i == len(cells) {
+			e.layout[bombs]++
+			for j, bomb := range layout {
+				if bomb {
+					e.bombs[bombs][j]++
+				}
+			}
+
+			return
+		}
+
+		for _, bomb := range []bool{false, true} {
+			valid := true
+			for _, c := range p.touching[cells[i]] {
+				pending[c]--
+				if bomb {
+					assigned[c]++
+				}
+
+				if assigned[c] > p.constraints[c].bombs || assigned[c]+pending[c] < p.constraints[c].bombs {
+					valid = false
+				}
+			}
+
+			if valid {
+				layout[i] = bomb
+				if bomb {
+					walk(i+1, bombs+1)
+				} else {
+					walk(i+1, bombs)
+				}
+			}
+
+			for _, c := range p.touching[cells[i]] {
+				pending[c]++
+				if bomb {
+					assigned[c]--
+				}
+			}
+		}
+
+		layout[i] = false
+	}
+
+	walk(0, 0)
+	if aborted {
+		return enumeration{}, false
+	}
+
+	total := 0.0
+	for _, count := range e.layout {
+		total += count
+	}
+
+	if total == 0 {
+		return enumeration{}, false
+	}
+
+	for k := range e.layout {
+		e.layout[k] /= total
+		for j := range e.bombs[k] {
+			e.bombs[k][j] /= total
+		}
+	}
+
+	return e, true
+}
+
+// monteCarlo estimates the probabilities from layouts drawn at random, one for every component. Some layouts are more
+// likely to be drawn than others, and the bombs of the components change the ways of placing the rest in the interior
+// cells, so every sample is weighted by how many layouts of the board it stands for over how likely it was to be drawn.
+// That way every valid layout of the board counts the same. Returns false if no sample is taken before expired
+func (p *problem) monteCarlo(expired func() bool, intn func(n int) int) ([]float64, int, bool) {
+	components := p.components()
+	s := &sampler{
+		problem:  p,
+		assigned: make([]int, len(p.constraints)),
+		pending:  make([]int, len(p.constraints)),
+		layout:   make([]bool, len(p.cells)),
+		expired:  expired,
+		intn:     intn,
+	}
+
+	if len(p.cells) > 0 {
+		s.threshold = int(math.Min(math.Max(float64(p.bombs)/float64(len(p.cells)), minSampleDensity), 1-minSampleDensity) * sampleResolution)
+	}
+
+	sums := make([]float64, len(p.cells))
+	interior, total := 0.0, 0.0
+	scale := math.Inf(-1) // log of the weight that all the sums are relative to
+	samples := 0
+
+	for n := 0; n%16 != 0 || !expired(); n++ {
+		drawn, bombs := 0.0, 0
+		aborted := false
+		for _, cells := range components {
+			probability, placed, ok := s.sample(cells)
+			if !ok {
+				aborted = true
+				break
+			}
+
+			drawn += probability
+			bombs += placed
+		}
+
+		if aborted {
+			break
+		}
+
+		left := p.bombs - bombs
+		if left < 0 || left > len(p.interior) {
+			continue
+		}
+
+		weight := logCombinations(len(p.interior), left) - drawn
+		if weight > scale {
+			factor := math.Exp(scale - weight)
+			for i := range sums {
+				sums[i] *= factor
+			}
+
+			interior *= factor
+			total *= factor
+			scale = weight
+		}
+
+		w := math.Exp(weight - scale)
+		for _, cells := range components {
+			for _, cell := range cells {
+				if s.layout[cell] {
+					sums[cell] += w
+				}
+			}
+		}
+
+		if len(p.interior) > 0 {
+			interior += w * float64(left) / float64(len(p.interior))
+		}
+
+		total += w
+		samples++
+	}
+
+	if samples == 0 {
+		return nil, 0, false
+	}
+
+	probabilities := make([]float64, len(p.cells))
+	for i := range probabilities {
+		probabilities[i] = sums[i] / total
+	}
+
+	for _, cell := range p.interior {
+		probabilities[cell] = interior / total
+	}
+
+	return probabilities, samples, true
+}
+
+const (
+	sampleResolution = 1 << 16
+	minSampleDensity = 0.05
+)
+
+// sampler draws layouts of the components deciding their cells one after the other
+type sampler struct {
+	*problem
+	assigned  []int
+	pending   []int
+	layout    []bool
+	threshold int // chances out of sampleResolution of placing a bomb when both choices are valid
+	expired   func() bool
+	intn      func(n int) int
+}
+
+// sample draws a layout of the given component satisfying the constraints it touches. A cell that can go either way
+// gets a bomb with the density of the bombs left, and the drawing starts over whenever a constraint cannot be met.
+// Returns the log of the probability of drawing the layout, leaving aside the drawings started over, and its bombs.
+// Returns false if expired before drawing a layout
+func (s *sampler) sample(cells []int) (float64, int, bool) {
+	chances := []float64{math.Log(1 - float64(s.threshold)/sampleResolution), math.Log(float64(s.threshold) / sampleResolution)}
+
+	for attempt := 1; attempt%16 != 0 || !s.expired(); attempt++ {
+		for _, cell := range cells {
+			for _, c := range s.touching[cell] {
+				s.assigned[c], s.pending[c] = 0, len(s.indexes[c])
+			}
+		}
+
+		probability, bombs := 0.0, 0
+		valid := true
+		for _, cell := range cells {
+			canBeEmpty, canBeBomb := s.allows(cell, false), s.allows(cell, true)
+
+			var bomb bool
+			switch {
+			case canBeEmpty && canBeBomb:
+				bomb = s.intn(sampleResolution) < s.threshold
+				if bomb {
+					probability += chances[1]
+				} else {
+					probability += chances[0]
+				}
+			case canBeEmpty || canBeBomb:
+				bomb = canBeBomb
+			default:
+				valid = false
+			}
+
+			if !valid {
+				break
+			}
+
+			s.layout[cell] = bomb
+			for _, c := range s.touching[cell] {
+				s.pending[c]--
+				if bomb {
+					s.assigned[c]++
+				}
+			}
+
+			if bomb {
+				bombs++
+			}
+		}
+
+		if valid {
+			return probability, bombs, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// allows returns true if the constraints of the given cell can still be met when it is decided as given
+func (s *sampler) allows(cell int, bomb bool) bool {
+	for _, c := range s.touching[cell] {
+		assigned := s.assigned[c]
+		if bomb {
+			assigned++
+		}
+
+		if assigned > s.constraints[c].bombs || assigned+s.pending[c]-1 < s.constraints[c].bombs {
+			return false
+		}
+	}
+
+	return true
+}
+
+func convolve(a []float64, b []float64) []float64 {
+	result := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		if x == 0 {
+			continue
+		}
+
+		for j, y := range b {
+			result[i+j] += x * y
+		}
+	}
+
+	return result
+}
+
+func logCombinations(n int, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+
+	return a - b - c
+}
@@ -0,0 +1,167 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+	boards := []struct {
+		name        string
+		board       domain.Board
+		bombsNumber int
+	}{
+		{
+			name: "single revealed cell",
+			board: domain.Board{
+				{E, e, e},
+				{e, b, e},
+			},
+			bombsNumber: 1,
+		},
+		{
+			name: "overlapping revealed cells and interior cells",
+			board: domain.Board{
+				{E, E, E, e, e},
+				{b, e, b, e, b},
+				{e, e, e, e, e},
+			},
+			bombsNumber: 3,
+		},
+		{
+			name: "separated groups of revealed cells",
+			board: domain.Board{
+				{E, e, e, e, E},
+				{b, e, e, e, b},
+				{e, e, b, e, e},
+				{E, b, e, e, E},
+			},
+			bombsNumber: 4,
+		},
+		{
+			name: "revealed cells whose constraints overlap in different directions",
+			board: domain.Board{
+				{e, b, e, e, e},
+				{b, e, e, e, e},
+				{E, E, e, b, e},
+				{e, e, b, E, b},
+			},
+			bombsNumber: 5,
+		},
+		{
+			name: "exploded bomb",
+			board: domain.Board{
+				{E, E, b},
+				{B, e, e},
+			},
+			bombsNumber: 2,
+		},
+	}
+
+	for _, tt := range boards {
+		tt := tt
+
+		t.Run(tt.name+" - exact", func(t *testing.T) {
+			want := bruteForce(tt.board, tt.bombsNumber)
+			now := time.Now()
+
+			analysis := domain.Analyze(tt.board, domain.StandardTopology, tt.bombsNumber, func() time.Time { return now }, time.Second, rand.New(rand.NewSource(1)).Intn)
+
+			assert.Equal(t, domain.AnalysisMethodExact, analysis.Method)
+			assert.Len(t, analysis.Cells, len(want))
+			for _, cell := range analysis.Cells {
+				assert.InDelta(t, want[cell.Position], cell.Probability, 1e-9, cell.Position.String())
+			}
+		})
+
+		t.Run(tt.name+" - monte carlo", func(t *testing.T) {
+			want := bruteForce(tt.board, tt.bombsNumber)
+
+			// the exact enumeration runs out of time at its first check, then the sampling runs for a while
+			start, calls := time.Now(), 0
+			now := func() time.Time {
+				calls++
+				switch {
+				case calls == 1:
+					return start
+				case calls <= 2000:
+					return start.Add(600 * time.Millisecond)
+				default:
+					return start.Add(time.Second)
+				}
+			}
+
+			analysis := domain.Analyze(tt.board, domain.StandardTopology, tt.bombsNumber, now, time.Second, rand.New(rand.NewSource(1)).Intn)
+
+			assert.Equal(t, domain.AnalysisMethodMonteCarlo, analysis.Method)
+			assert.True(t, analysis.Samples > 0)
+			assert.Len(t, analysis.Cells, len(want))
+			for _, cell := range analysis.Cells {
+				assert.InDelta(t, want[cell.Position], cell.Probability, 0.05, cell.Position.String())
+			}
+		})
+	}
+}
+
+// bruteForce computes the probabilities trying every layout of the bombs left among the covered cells
+func bruteForce(board domain.Board, bombsNumber int) map[domain.Position]float64 {
+	var covered []domain.Position
+	for row := range board {
+		for column := range board[0] {
+			if board.IsCovered(domain.NewPosition(row, column)) {
+				covered = append(covered, domain.NewPosition(row, column))
+			}
+		}
+	}
+
+	remaining := bombsNumber - board.Count(domain.BombCellRevealed)
+	counts := map[domain.Position]float64{}
+	total := 0.0
+
+	for mask := 0; mask < 1<<len(covered); mask++ {
+		candidate := board.Copy()
+		bombs := 0
+		for i, pos := range covered {
+			candidate.Set(pos, domain.EmptyCellCovered)
+			if mask&(1<<i) != 0 {
+				candidate.Set(pos, domain.BombCellCovered)
+				bombs++
+			}
+		}
+
+		if bombs != remaining || !matches(board, candidate) {
+			continue
+		}
+
+		total++
+		for i, pos := range covered {
+			if mask&(1<<i) != 0 {
+				counts[pos]++
+			}
+		}
+	}
+
+	result := map[domain.Position]float64{}
+	for _, pos := range covered {
+		result[pos] = counts[pos] / total
+	}
+
+	return result
+}
+
+// matches returns true if every revealed cell shows the same number in both boards
+func matches(board domain.Board, candidate domain.Board) bool {
+	for row := range board {
+		for column := range board[0] {
+			pos := domain.NewPosition(row, column)
+			if board.Is(pos, domain.EmptyCellRevealed) && board.CountNeighborBombs(domain.StandardTopology, pos) != candidate.CountNeighborBombs(domain.StandardTopology, pos) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
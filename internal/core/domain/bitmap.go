@@ -0,0 +1,26 @@
+package domain
+
+// bitmap is a set of cells of a board packed in bits, one per cell in row-major order
+type bitmap struct {
+	columns int
+	words   []uint64
+}
+
+func newBitmap(board Board) bitmap {
+	rows, columns := len(board), 0
+	if rows > 0 {
+		columns = len(board[0])
+	}
+
+	return bitmap{columns: columns, words: make([]uint64, (rows*columns+63)/64)}
+}
+
+func (bm bitmap) has(pos Position) bool {
+	i := pos.Row*bm.columns + pos.Column
+	return bm.words[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (bm bitmap) add(pos Position) {
+	i := pos.Row*bm.columns + pos.Column
+	bm.words[i/64] |= 1 << uint(i%64)
+}
@@ -1,13 +1,17 @@
 package domain
 
-const (
-	EmptyCellCovered          = Cell('e')
-	EmptyCellCoveredAndMarked = Cell('X')
-	EmptyCellRevealed         = Cell('E')
+import "fmt"
 
-	BombCellCovered          = Cell('b')
-	BombCellCoveredAndMarked = Cell('Y')
-	BombCellRevealed         = Cell('B')
+const (
+	EmptyCellCovered              = Cell('e')
+	EmptyCellCoveredAndMarked     = Cell('X')
+	EmptyCellCoveredAndQuestioned = Cell('Q')
+	EmptyCellRevealed             = Cell('E')
+
+	BombCellCovered              = Cell('b')
+	BombCellCoveredAndMarked     = Cell('Y')
+	BombCellCoveredAndQuestioned = Cell('R')
+	BombCellRevealed             = Cell('B')
 )
 
 type Board [][]Cell
@@ -15,8 +19,8 @@ type Board [][]Cell
 type Cell string
 
 type Position struct {
-	Row    int
-	Column int
+	Row    int `json:"row"`
+	Column int `json:"column"`
 }
 
 func NewEmptyBoard(rows int, columns int) Board {
@@ -38,6 +42,10 @@ func NewPosition(row int, column int) Position {
 	return Position{Row: row, Column: column}
 }
 
+func (pos Position) String() string {
+	return fmt.Sprintf("(%d, %d)", pos.Row, pos.Column)
+}
+
 // Get retrieves the element in the given position
 func (board Board) Get(pos Position) Cell {
 	return board[pos.Row][pos.Column]
@@ -60,56 +68,181 @@ func (board Board) Is(pos Position, elements ...Cell) bool {
 	return false
 }
 
+// HasBomb returns true if there is a bomb in the given position, no matter if it is covered, marked or exploded
+func (board Board) HasBomb(pos Position) bool {
+	return board.Is(pos, BombCellCovered, BombCellCoveredAndMarked, BombCellCoveredAndQuestioned, BombCellRevealed)
+}
+
+// IsRevealable returns true if the cell in the given position is covered and not marked with a flag.
+// Cells marked with a question are revealable, they are just a reminder for the player
+func (board Board) IsRevealable(pos Position) bool {
+	return board.Is(pos, EmptyCellCovered, EmptyCellCoveredAndQuestioned, BombCellCovered, BombCellCoveredAndQuestioned)
+}
+
+// PlaceBomb puts a covered bomb in the given position, keeping the mark made by the player if any
+func (board Board) PlaceBomb(pos Position) {
+	switch board.Get(pos) {
+	case EmptyCellCoveredAndMarked:
+		board.Set(pos, BombCellCoveredAndMarked)
+	case EmptyCellCoveredAndQuestioned:
+		board.Set(pos, BombCellCoveredAndQuestioned)
+	default:
+		board.Set(pos, BombCellCovered)
+	}
+}
+
 // IsValidPosition returns true if the given position is within the range of the board; returns false otherwise
 func (board Board) IsValidPosition(pos Position) bool {
 	return pos.Row >= 0 && pos.Column >= 0 && pos.Row < len(board) && pos.Column < len(board[0])
 }
 
-// GetNeighborsIfNoBombs returns the neighbors of the given position or empty if at least one neighbor has a bomb
-func (board Board) GetNeighborsIfNoBombs(pos Position) []Position {
+// GetNeighborsIfNoBombs returns the covered neighbors of the given position that are not marked with a flag, or empty
+// if at least one neighbor has a bomb
+func (board Board) GetNeighborsIfNoBombs(topology Topology, pos Position) []Position {
 	var neighbors []Position
-	var current Position
 
-	for di := -1; di <= 1; di++ {
-		for dj := -1; dj <= 1; dj++ {
-			current = NewPosition(pos.Row-di, pos.Column-dj)
+	all := board.Neighbors(topology, pos)
+	if board.anyBomb(all) {
+		return []Position{}
+	}
 
-			if current == pos || !board.IsValidPosition(current) {
-				continue
-			}
+	for i := len(all) - 1; i >= 0; i-- {
+		if board.Is(all[i], EmptyCellCovered, EmptyCellCoveredAndQuestioned) {
+			neighbors = append(neighbors, all[i])
+		}
+	}
 
-			if board.Is(current, BombCellCovered) {
-				return []Position{}
-			}
+	return neighbors
+}
+
+// Neighbors returns the valid positions around the given position
+func (board Board) Neighbors(topology Topology, pos Position) []Position {
+	return topology.AppendNeighbors(nil, board, pos)
+}
+
+// CountNeighborBombs counts the bombs around the given position
+func (board Board) CountNeighborBombs(topology Topology, pos Position) int {
+	count := 0
+	for _, neighbor := range board.Neighbors(topology, pos) {
+		if board.HasBomb(neighbor) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// IsCovered returns true if the cell in the given position has not been revealed yet
+func (board Board) IsCovered(pos Position) bool {
+	return board.Is(pos, EmptyCellCovered, EmptyCellCoveredAndMarked, EmptyCellCoveredAndQuestioned,
+		BombCellCovered, BombCellCoveredAndMarked, BombCellCoveredAndQuestioned)
+}
+
+// RevealInCascade reveals the given position and, repeatedly, the adjacent cells of every revealed cell without bombs
+// as neighbors. Cells marked with a question are revealed as any covered cell, while cells marked with a flag are kept.
+// The cells are visited in breadth-first order with a queue, so the stack does not grow with the size of the region.
+// Returns the positions that have been revealed
+func (board Board) RevealInCascade(topology Topology, pos Position) []Position {
+	var revealed []Position
+	var neighbors []Position
 
-			if !board.Is(current, EmptyCellCovered) {
-				continue
+	visited := newBitmap(board)
+	visited.add(pos)
+	queue := []Position{pos}
+
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
+
+		if board.Is(current, EmptyCellCovered, EmptyCellCoveredAndQuestioned) {
+			board.Set(current, EmptyCellRevealed)
+			revealed = append(revealed, current)
+		}
+
+		neighbors = topology.AppendNeighbors(neighbors[:0], board, current)
+		if board.anyBomb(neighbors) {
+			continue
+		}
+
+		for _, neighbor := range neighbors {
+			if !visited.has(neighbor) && board.Is(neighbor, EmptyCellCovered, EmptyCellCoveredAndQuestioned) {
+				visited.add(neighbor)
+				queue = append(queue, neighbor)
 			}
+		}
+	}
 
-			neighbors = append(neighbors, current)
+	return revealed
+}
+
+// anyBomb returns true if there is a bomb in at least one of the given positions
+func (board Board) anyBomb(positions []Position) bool {
+	for _, pos := range positions {
+		if board.HasBomb(pos) {
+			return true
 		}
 	}
 
-	return neighbors
+	return false
 }
 
-// HideBombs replace bombs for empty cells
-func (board Board) HideBombs() {
+// Copy returns a deep copy of the board
+func (board Board) Copy() Board {
+	result := make([][]Cell, len(board))
+	for row := range board {
+		result[row] = make([]Cell, len(board[row]))
+		copy(result[row], board[row])
+	}
+
+	return result
+}
+
+// Layout returns a copy of the board with every cell covered and unmarked, keeping the bombs and the disabled cells
+// where they are
+func (board Board) Layout() Board {
+	result := NewEmptyBoard(len(board), len(board[0]))
 	for row := range board {
 		for column := range board[0] {
-			if board.Is(NewPosition(row, column), BombCellCovered) {
-				board.Set(NewPosition(row, column), EmptyCellCovered)
+			switch pos := NewPosition(row, column); {
+			case board.HasBomb(pos):
+				result.Set(pos, BombCellCovered)
+			case board.Is(pos, DisabledCell):
+				result.Set(pos, DisabledCell)
 			}
 		}
 	}
+
+	return result
+}
+
+// HideBombs replace covered bombs for empty cells, keeping the marks made by the player
+func (board Board) HideBombs() {
+	for row := range board {
+		for column := range board[0] {
+			board[row][column] = board[row][column].Hide()
+		}
+	}
+}
+
+// Hide returns the cell as seen by the player: a covered bomb looks like a covered empty cell with the same mark
+func (cell Cell) Hide() Cell {
+	switch cell {
+	case BombCellCovered:
+		return EmptyCellCovered
+	case BombCellCoveredAndMarked:
+		return EmptyCellCoveredAndMarked
+	case BombCellCoveredAndQuestioned:
+		return EmptyCellCoveredAndQuestioned
+	default:
+		return cell
+	}
 }
 
-// Count counts the elements of given type
-func (board Board) Count(element Cell) int {
+// Count counts the elements of any of the given types
+func (board Board) Count(elements ...Cell) int {
 	count := 0
 	for row := range board {
 		for column := range board[0] {
-			if board[row][column] == element {
+			if board.Is(NewPosition(row, column), elements...) {
 				count++
 			}
 		}
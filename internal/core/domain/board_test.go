@@ -10,6 +10,11 @@ var (
 	e = domain.EmptyCellCovered
 	b = domain.BombCellCovered
 	E = domain.EmptyCellRevealed
+	Y = domain.BombCellCoveredAndMarked
+	B = domain.BombCellRevealed
+	X = domain.EmptyCellCoveredAndMarked
+	Q = domain.EmptyCellCoveredAndQuestioned
+	R = domain.BombCellCoveredAndQuestioned
 )
 
 func TestNewEmptyBoard(t *testing.T) {
@@ -72,6 +77,47 @@ func TestBoard_Set(t *testing.T) {
 	assert.Equal(t, b, board[1][2])
 }
 
+func TestBoard_HasBomb(t *testing.T) {
+	board := domain.Board{
+		{e, b, Y, B, E, X, Q, R},
+	}
+
+	want := []bool{false, true, true, true, false, false, false, true}
+
+	for column, expected := range want {
+		assert.Equal(t, expected, board.HasBomb(domain.NewPosition(0, column)))
+	}
+}
+
+func TestBoard_IsRevealable(t *testing.T) {
+	board := domain.Board{
+		{e, b, Y, B, E, X, Q, R},
+	}
+
+	want := []bool{true, true, false, false, false, false, true, true}
+
+	for column, expected := range want {
+		assert.Equal(t, expected, board.IsRevealable(domain.NewPosition(0, column)))
+	}
+}
+
+func TestBoard_PlaceBomb(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, X, Q},
+	}
+
+	// Execute
+	board.PlaceBomb(domain.NewPosition(0, 0))
+	board.PlaceBomb(domain.NewPosition(0, 1))
+	board.PlaceBomb(domain.NewPosition(0, 2))
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{b, Y, R},
+	}, board)
+}
+
 func TestBoard_IsValidPosition(t *testing.T) {
 	board := domain.Board{
 		{e, e, e, e, b, e},
@@ -144,6 +190,12 @@ func TestBoard_GetNeighborsIfNoBombs(t *testing.T) {
 		{e, e, e, e, b, e},
 	}
 
+	boardWithMarkedAndExplodedBombs := domain.Board{
+		{e, e, e, e, e, e},
+		{e, e, e, e, e, e},
+		{e, Y, e, e, B, e},
+	}
+
 	type args struct {
 		board  domain.Board
 		row    int
@@ -187,25 +239,182 @@ func TestBoard_GetNeighborsIfNoBombs(t *testing.T) {
 			args: args{board: boardWithBombs, row: 1, column: 1},
 			want: want{result: []domain.Position{}},
 		},
+		{
+			name: "get empty due to at least one neighbor has a marked bomb",
+			args: args{board: boardWithMarkedAndExplodedBombs, row: 1, column: 1},
+			want: want{result: []domain.Position{}},
+		},
+		{
+			name: "get empty due to at least one neighbor has an exploded bomb",
+			args: args{board: boardWithMarkedAndExplodedBombs, row: 1, column: 4},
+			want: want{result: []domain.Position{}},
+		},
 	}
 
 	for _, tt := range tests {
 		tt := tt
 
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.args.board.GetNeighborsIfNoBombs(domain.NewPosition(tt.args.row, tt.args.column))
+			got := tt.args.board.GetNeighborsIfNoBombs(domain.StandardTopology, domain.NewPosition(tt.args.row, tt.args.column))
 
 			assert.Equal(t, tt.want.result, got)
 		})
 	}
 }
 
+func TestBoard_CountNeighborBombs(t *testing.T) {
+	board := domain.Board{
+		{e, e, e, e, b, e},
+		{e, b, e, e, e, b},
+		{b, e, e, e, E, e},
+	}
+
+	assert.Equal(t, 2, board.CountNeighborBombs(domain.StandardTopology, domain.NewPosition(1, 0)))
+	assert.Equal(t, 1, board.CountNeighborBombs(domain.StandardTopology, domain.NewPosition(0, 0)))
+	assert.Equal(t, 2, board.CountNeighborBombs(domain.StandardTopology, domain.NewPosition(1, 4)))
+	assert.Equal(t, 0, board.CountNeighborBombs(domain.StandardTopology, domain.NewPosition(2, 3)))
+}
+
+func TestBoard_RevealInCascade(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, e, e, e, e, e},
+		{e, e, e, e, e, e},
+		{e, e, e, b, e, e},
+		{e, e, e, e, e, b},
+	}
+
+	// Execute
+	revealed := board.RevealInCascade(domain.StandardTopology, domain.NewPosition(0, 0))
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{E, E, E, E, E, E},
+		{E, E, E, E, E, E},
+		{E, E, E, b, E, E},
+		{E, E, E, e, e, b},
+	}, board)
+	assert.Len(t, revealed, 20)
+	assert.Contains(t, revealed, domain.NewPosition(0, 0))
+	assert.Contains(t, revealed, domain.NewPosition(3, 2))
+	assert.NotContains(t, revealed, domain.NewPosition(3, 3))
+}
+
+func TestBoard_RevealInCascade_WithMarks(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, Q, e, e, e, e},
+		{e, e, X, e, e, e},
+		{e, e, e, b, e, e},
+		{e, e, e, e, e, b},
+	}
+
+	// Execute
+	board.RevealInCascade(domain.StandardTopology, domain.NewPosition(0, 0))
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{E, E, E, E, E, E},
+		{E, E, X, E, E, E},
+		{E, E, E, b, E, E},
+		{E, E, E, e, e, b},
+	}, board)
+}
+
+func TestBoard_RevealInCascade_LargeBoard(t *testing.T) {
+	// Setup
+	board := domain.NewEmptyBoard(1000, 1000)
+
+	// Execute
+	revealed := board.RevealInCascade(domain.StandardTopology, domain.NewPosition(500, 500))
+
+	// Verify
+	assert.Len(t, revealed, 1000*1000)
+	assert.Equal(t, 1000*1000, board.Count(E))
+}
+
+func BenchmarkBoard_RevealInCascade(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		board := newSparseBoard(1000, 1000)
+		b.StartTimer()
+
+		board.RevealInCascade(domain.StandardTopology, domain.NewPosition(0, 0))
+	}
+}
+
+func BenchmarkBoard_Metrics(b *testing.B) {
+	board := newSparseBoard(1000, 1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		board.Metrics(domain.StandardTopology)
+	}
+}
+
+// newSparseBoard returns a board with a bomb every 97 cells, so that most of it is opened by a single cascade
+func newSparseBoard(rows int, columns int) domain.Board {
+	board := domain.NewEmptyBoard(rows, columns)
+	for i := 96; i < rows*columns; i += 97 {
+		board.Set(domain.NewPosition(i/columns, i%columns), domain.BombCellCovered)
+	}
+
+	return board
+}
+
+func TestBoard_Copy(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, e, b},
+		{E, e, e},
+	}
+
+	// Execute
+	result := board.Copy()
+	result.Set(domain.NewPosition(0, 0), b)
+
+	// Verify
+	assert.Equal(t, domain.Board{{b, e, b}, {E, e, e}}, result)
+	assert.Equal(t, domain.Board{{e, e, b}, {E, e, e}}, board)
+}
+
+func TestCell_Hide(t *testing.T) {
+	cells := []domain.Cell{e, b, E, B, X, Y, Q, R}
+	want := []domain.Cell{e, e, E, B, X, X, Q, Q}
+
+	for i, cell := range cells {
+		assert.Equal(t, want[i], cell.Hide())
+	}
+}
+
+func TestBoard_Layout(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, E, X, b, D},
+		{Q, Y, R, B, e},
+	}
+
+	// Execute
+	layout := board.Layout()
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{e, e, e, b, D},
+		{e, b, b, b, e},
+	}, layout)
+	assert.Equal(t, domain.Board{
+		{e, E, X, b, D},
+		{Q, Y, R, B, e},
+	}, board)
+}
+
 func TestBoard_HideBombs(t *testing.T) {
 	// Setup
 	board := domain.Board{
 		{e, E, e, b, e, b},
 		{e, e, b, e, b, e},
 		{b, e, e, b, e, e},
+		{X, Y, Q, R, B, e},
 	}
 
 	// Execute
@@ -216,5 +425,17 @@ func TestBoard_HideBombs(t *testing.T) {
 		{e, E, e, e, e, e},
 		{e, e, e, e, e, e},
 		{e, e, e, e, e, e},
+		{X, X, Q, Q, B, e},
 	}, board)
 }
+
+func TestBoard_Count(t *testing.T) {
+	board := domain.Board{
+		{e, b, Y, B, E},
+		{X, Q, R, E, E},
+	}
+
+	assert.Equal(t, 3, board.Count(E))
+	assert.Equal(t, 2, board.Count(X, Y))
+	assert.Equal(t, 0, board.Count())
+}
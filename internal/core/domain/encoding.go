@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+)
+
+// cellCodes are the 4 bits used to encode every kind of cell; the position in the list is the code
+var cellCodes = []Cell{
+	EmptyCellCovered, EmptyCellCoveredAndMarked, EmptyCellCoveredAndQuestioned, EmptyCellRevealed,
+	BombCellCovered, BombCellCoveredAndMarked, BombCellCoveredAndQuestioned, BombCellRevealed,
+	DisabledCell,
+}
+
+// formats of the cells of an encoded board
+const (
+	formatPacked byte = iota
+	formatRuns
+)
+
+const (
+	encodedHeaderSize = 9
+	maxShortRun       = 15
+
+	// maxEncodedPositionSize bounds the bytes a position takes once stored, along with the names of its fields
+	maxEncodedPositionSize = 32
+)
+
+// Encode packs the board into bytes: the number of rows and columns as two 32 bits integers, a byte with the format
+// of the cells and the cells in row-major order, whichever format is shorter. Cells are either packed at 4 bits per
+// cell or grouped in runs of equal cells. A run takes a byte with the code of its cell and its length up to 15,
+// followed by a varint with the rest of the length of longer runs, so the big regions of a board take a few bytes.
+// A board never takes more than half a byte per cell. Returns an error if the board has a cell of an unknown kind
+func (board Board) Encode() ([]byte, error) {
+	rows, columns := len(board), 0
+	if rows > 0 {
+		columns = len(board[0])
+	}
+
+	header := make([]byte, encodedHeaderSize)
+	binary.BigEndian.PutUint32(header[0:4], uint32(rows))
+	binary.BigEndian.PutUint32(header[4:8], uint32(columns))
+
+	packedSize := (rows*columns + 1) / 2
+	runs, ok, err := board.encodeRuns(packedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		header[8] = formatRuns
+		return append(header, runs...), nil
+	}
+
+	packed, err := board.encodePacked()
+	if err != nil {
+		return nil, err
+	}
+
+	header[8] = formatPacked
+	return append(header, packed...), nil
+}
+
+// encodeRuns groups the cells in runs of equal cells. Returns false as soon as the runs take more than limit bytes
+func (board Board) encodeRuns(limit int) ([]byte, bool, error) {
+	var data []byte
+	varint := make([]byte, binary.MaxVarintLen64)
+
+	code, length := byte(0), 0
+	flush := func() {
+		if length == 0 {
+			return
+		}
+
+		if length <= maxShortRun {
+			data = append(data, code<<4|byte(length-1))
+			return
+		}
+
+		data = append(data, code<<4|maxShortRun)
+		data = append(data, varint[:binary.PutUvarint(varint, uint64(length-maxShortRun-1))]...)
+	}
+
+	for row := range board {
+		for column := range board[row] {
+			c, err := cellCode(board, NewPosition(row, column))
+			if err != nil {
+				return nil, false, err
+			}
+
+			if length > 0 && c == code {
+				length++
+				continue
+			}
+
+			flush()
+			if len(data) > limit {
+				return nil, false, nil
+			}
+
+			code, length = c, 1
+		}
+	}
+
+	flush()
+
+	return data, len(data) <= limit, nil
+}
+
+func (board Board) encodePacked() ([]byte, error) {
+	rows, columns := len(board), 0
+	if rows > 0 {
+		columns = len(board[0])
+	}
+
+	data := make([]byte, (rows*columns+1)/2)
+	for row := range board {
+		for column := range board[row] {
+			c, err := cellCode(board, NewPosition(row, column))
+			if err != nil {
+				return nil, err
+			}
+
+			i := row*columns + column
+			data[i/2] |= c << uint(4*(i%2))
+		}
+	}
+
+	return data, nil
+}
+
+// EncodedSize returns the most bytes the boards and the mask of a game with the given settings take once encoded: its
+// board and, for practice games, the boards remembered to undo up to historySize moves, at half a byte per cell at most,
+// plus the layout of the mask, at a byte per cell, and the cells it lists
+func (settings GameSettings) EncodedSize(historySize int) int {
+	boards := 1
+	if settings.Practice {
+		boards += historySize
+	}
+
+	size := boards * (encodedHeaderSize + (settings.Rows*settings.Columns+1)/2)
+	for _, line := range settings.Mask.Layout {
+		size += len(line)
+	}
+
+	return size + len(settings.Mask.Cells)*maxEncodedPositionSize
+}
+
+// DecodeBoard unpacks a board encoded with Encode
+func DecodeBoard(data []byte) (Board, error) {
+	if len(data) < encodedHeaderSize {
+		return nil, fmt.Errorf("encoded board is too short: %d bytes", len(data))
+	}
+
+	rows, columns := binary.BigEndian.Uint32(data[0:4]), binary.BigEndian.Uint32(data[4:8])
+
+	switch data[8] {
+	case formatPacked:
+		return decodePacked(data[encodedHeaderSize:], rows, columns)
+	case formatRuns:
+		return decodeRuns(data[encodedHeaderSize:], rows, columns)
+	default:
+		return nil, fmt.Errorf("invalid encoded board format %d", data[8])
+	}
+}
+
+// DecodeBoardString unpacks a board stored as a base64 string by older versions: the number of rows and columns as two
+// 32 bits integers followed by the cells packed at 4 bits per cell
+func DecodeBoardString(encoded string) (Board, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 8 {
+		return nil, fmt.Errorf("encoded board is too short: %d bytes", len(data))
+	}
+
+	return decodePacked(data[8:], binary.BigEndian.Uint32(data[0:4]), binary.BigEndian.Uint32(data[4:8]))
+}
+
+func decodePacked(data []byte, rows uint32, columns uint32) (Board, error) {
+	if uint64(len(data)) != (uint64(rows)*uint64(columns)+1)/2 {
+		return nil, fmt.Errorf("encoded board of %dx%d cells has %d bytes of cells", rows, columns, len(data))
+	}
+
+	board := NewEmptyBoard(int(rows), int(columns))
+	for row := range board {
+		for column := range board[row] {
+			i := row*int(columns) + column
+			code := int(data[i/2]>>uint(4*(i%2))) & 0x0f
+			if code >= len(cellCodes) {
+				return nil, fmt.Errorf("invalid cell code %d at %s", code, NewPosition(row, column))
+			}
+
+			board[row][column] = cellCodes[code]
+		}
+	}
+
+	return board, nil
+}
+
+func decodeRuns(data []byte, rows uint32, columns uint32) (Board, error) {
+	type run struct {
+		cell   Cell
+		length uint64
+	}
+
+	var runs []run
+	total := uint64(rows) * uint64(columns)
+	cells := uint64(0)
+
+	for i := 0; i < len(data); {
+		code, length := int(data[i]>>4), uint64(data[i]&0x0f)+1
+		i++
+
+		if code >= len(cellCodes) {
+			return nil, fmt.Errorf("invalid cell code %d in run %d", code, len(runs))
+		}
+
+		if length > maxShortRun {
+			rest, n := binary.Uvarint(data[i:])
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid length of run %d", len(runs))
+			}
+
+			length += rest
+			i += n
+		}
+
+		if cells += length; cells > total || cells < length {
+			return nil, fmt.Errorf("encoded board of %dx%d cells has more cells", rows, columns)
+		}
+
+		runs = append(runs, run{cell: cellCodes[code], length: length})
+	}
+
+	if cells != total {
+		return nil, fmt.Errorf("encoded board of %dx%d cells has %d cells", rows, columns, cells)
+	}
+
+	board := NewEmptyBoard(int(rows), int(columns))
+	i := 0
+	for _, r := range runs {
+		for n := uint64(0); n < r.length; n, i = n+1, i+1 {
+			board[i/int(columns)][i%int(columns)] = r.cell
+		}
+	}
+
+	return board, nil
+}
+
+// cellCode returns the code of the cell in the given position; returns an error if the cell is of an unknown kind
+func cellCode(board Board, pos Position) (byte, error) {
+	cell := board.Get(pos)
+	for code, c := range cellCodes {
+		if c == cell {
+			return byte(code), nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid cell %q at %s", cell, pos)
+}
@@ -0,0 +1,156 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBoard_Encode(t *testing.T) {
+	longRuns := domain.NewEmptyBoard(40, 50)
+	for column := 0; column < 50; column++ {
+		longRuns[10][column] = E
+	}
+
+	longRuns[20][7], longRuns[39][49] = b, D
+
+	tests := []struct {
+		name  string
+		board domain.Board
+	}{
+		{
+			name: "board with every kind of cell",
+			board: domain.Board{
+				{e, X, Q, E, D},
+				{b, Y, R, B, D},
+			},
+		},
+		{
+			name: "board with an odd number of cells",
+			board: domain.Board{
+				{E, b, e},
+				{e, X, E},
+				{Q, e, B},
+			},
+		},
+		{
+			name:  "board with long runs of cells",
+			board: longRuns,
+		},
+		{
+			name:  "empty board",
+			board: domain.Board{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.board.Encode()
+			assert.Nil(t, err)
+
+			decoded, err := domain.DecodeBoard(encoded)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.board, decoded)
+		})
+	}
+}
+
+func TestBoard_Encode_Size(t *testing.T) {
+	alternating := domain.NewEmptyBoard(1000, 1000)
+	for row := range alternating {
+		for column := range alternating[row] {
+			if (row+column)%2 == 0 {
+				alternating[row][column] = E
+			}
+		}
+	}
+
+	sparse := domain.NewEmptyBoard(1000, 1000)
+	sparse[500][500], sparse[999][0] = b, X
+
+	encoded, err := alternating.Encode()
+	assert.Nil(t, err)
+	assert.Len(t, encoded, 9+1000*1000/2)
+
+	encoded, err = sparse.Encode()
+	assert.Nil(t, err)
+	assert.True(t, len(encoded) < 32)
+}
+
+func TestBoard_Encode_InvalidCell(t *testing.T) {
+	encoded, err := domain.Board{{E, "?", e}}.Encode()
+
+	assert.Nil(t, encoded)
+	assert.EqualError(t, err, `invalid cell "?" at (0, 1)`)
+}
+
+func TestDecodeBoardString(t *testing.T) {
+	board, err := domain.DecodeBoardString("AAAAAgAAAANDEHg=")
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Board{{E, b, e}, {X, D, B}}, board)
+}
+
+func TestDecodeBoard_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded []byte
+	}{
+		{name: "too short", encoded: []byte{0, 0, 0, 1}},
+		{name: "invalid format", encoded: []byte{0, 0, 0, 1, 0, 0, 0, 1, 7, 0}},
+		{name: "packed cells missing", encoded: []byte{0, 0, 0, 1, 0, 0, 0, 3, 0, 0}},
+		{name: "invalid packed cell code", encoded: []byte{0, 0, 0, 1, 0, 0, 0, 1, 0, 0x0f}},
+		{name: "run cells missing", encoded: []byte{0, 0, 0, 2, 0, 0, 0, 2, 1, 0x02}},
+		{name: "run cells left over", encoded: []byte{0, 0, 0, 2, 0, 0, 0, 2, 1, 0x04}},
+		{name: "invalid run cell code", encoded: []byte{0, 0, 0, 1, 0, 0, 0, 1, 1, 0xf0}},
+		{name: "long run without length", encoded: []byte{0, 0, 0, 4, 0, 0, 0, 4, 1, 0x0f}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.DecodeBoard(tt.encoded)
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestGameSettings_EncodedSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings domain.GameSettings
+		want     int
+	}{
+		{
+			name:     "board",
+			settings: domain.GameSettings{Rows: 3, Columns: 3},
+			want:     9 + 5,
+		},
+		{
+			name:     "practice board with its history",
+			settings: domain.GameSettings{Rows: 3, Columns: 3, Practice: true},
+			want:     11 * (9 + 5),
+		},
+		{
+			name: "board with mask",
+			settings: domain.GameSettings{Rows: 2, Columns: 4, Mask: domain.Mask{
+				Cells:  []domain.Position{domain.NewPosition(0, 1)},
+				Layout: []string{".##.", "####"},
+			}},
+			want: 9 + 4 + 8 + 32,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.settings.EncodedSize(10))
+		})
+	}
+}
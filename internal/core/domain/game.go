@@ -7,20 +7,134 @@ const (
 	GameStateOnGoing = "ongoing"
 	GameStateLost    = "lost"
 	GameStateWon     = "won"
+	GameStateTimeout = "timeout"
+)
+
+const (
+	FirstClickNeighborhood = "neighborhood"
+	FirstClickCell         = "cell"
+	FirstClickNone         = "none"
 )
 
 type Game struct {
-	ID        string       `json:"id"`
-	UserID    string       `json:"user_id"`
-	Board     Board        `json:"board"`
-	Settings  GameSettings `json:"settings"`
-	State     string       `json:"state"`
-	StartedAt time.Time    `json:"started_at"`
-	EndedAt   time.Time    `json:"ended_at"`
+	ID             string         `json:"id"`
+	UserID         string         `json:"user_id"`
+	SourceGameID   string         `json:"source_game_id"`
+	MatchID        string         `json:"match_id"`
+	Participants   []string       `json:"participants,omitempty"`
+	Board          Board          `json:"board"`
+	Settings       GameSettings   `json:"settings"`
+	State          string         `json:"state"`
+	RemainingLives int            `json:"remaining_lives"`
+	HintsUsed      int            `json:"hints_used"`
+	Moves          int            `json:"moves"`
+	Metrics        BoardMetrics   `json:"metrics"`
+	Performance    Performance    `json:"performance"`
+	Undos          int            `json:"undos"`
+	History        []Snapshot     `json:"history,omitempty"`
+	Log            []ActionRecord `json:"log,omitempty"`
+	Version        int            `json:"version"`
+	StartedAt      time.Time      `json:"started_at"`
+	EndedAt        time.Time      `json:"ended_at"`
+}
+
+// Progress summarizes what is left to finish the game, so clients do not have to scan the board
+type Progress struct {
+	MinesRemaining     int     `json:"mines_remaining"`
+	CoveredCells       int     `json:"covered_cells"`
+	RevealedPercentage float64 `json:"revealed_percentage"`
+	ElapsedSeconds     int     `json:"elapsed_seconds"`
+}
+
+// Performance rates how well a won game has been played
+type Performance struct {
+	Efficiency       float64 `json:"efficiency"`
+	ThreeBVPerSecond float64 `json:"3bv_per_second"`
 }
 
 type GameSettings struct {
-	Rows        int `json:"rows"`
-	Columns     int `json:"columns"`
-	BombsNumber int `json:"bombs_number"`
+	Rows          int    `json:"rows"`
+	Columns       int    `json:"columns"`
+	BombsNumber   int    `json:"bombs_number"`
+	TimeLimit     int    `json:"time_limit"`
+	Lives         int    `json:"lives"`
+	NoGuess       bool   `json:"no_guess"`
+	NoGuessBudget int    `json:"no_guess_budget"`
+	FirstClick    string `json:"first_click"`
+	Practice      bool   `json:"practice"`
+	QuestionMarks bool   `json:"question_marks"`
+	Topology      string `json:"topology"`
+	Mask          Mask   `json:"mask"`
+	PuzzleID      string `json:"puzzle_id"`
+}
+
+// IsFinished returns true if the game is over, no matter the result
+func (game Game) IsFinished() bool {
+	return game.State == GameStateLost || game.State == GameStateWon || game.State == GameStateTimeout
+}
+
+// isPlayedFairly is the rule every ranking applies. Practice games are left out, since their moves can be undone, as
+// well as retried games, since their bombs may already be known, match games, whose first click is not protected,
+// shared games, which are not played by a single player, and the games with extra lives or hints used
+func (game Game) isPlayedFairly() bool {
+	return !game.Settings.Practice && game.SourceGameID == "" && game.MatchID == "" && !game.IsShared() &&
+		game.Settings.Lives <= 1 && game.HintsUsed == 0
+}
+
+// Topology returns the topology selected in the settings of the game, which is the standard one if none was selected
+func (game Game) Topology() Topology {
+	if topology, ok := NewTopology(game.Settings.Topology); ok {
+		return topology
+	}
+
+	return StandardTopology
+}
+
+// Deadline returns the time when an ongoing game with time limit expires; returns false if the game cannot expire
+func (game Game) Deadline() (time.Time, bool) {
+	if game.State != GameStateOnGoing || game.Settings.TimeLimit <= 0 {
+		return time.Time{}, false
+	}
+
+	return game.StartedAt.Add(time.Duration(game.Settings.TimeLimit) * time.Second), true
+}
+
+// Rate computes the performance of the game comparing the minimum number of clicks of its board with the moves made and
+// the time spent. It is meaningful only once the game has been won
+func (game Game) Rate() Performance {
+	var performance Performance
+
+	if game.Moves > 0 {
+		performance.Efficiency = float64(game.Metrics.ThreeBV) / float64(game.Moves)
+	}
+
+	if elapsed := game.EndedAt.Sub(game.StartedAt).Seconds(); elapsed > 0 {
+		performance.ThreeBVPerSecond = float64(game.Metrics.ThreeBV) / elapsed
+	}
+
+	return performance
+}
+
+// Progress computes the progress of the game at the given time. The mines remaining are the bombs that have been
+// neither flagged nor exploded, so it may be negative when there are more flags than bombs
+func (game Game) Progress(now time.Time) Progress {
+	progress := Progress{
+		MinesRemaining: game.Settings.BombsNumber - game.Board.Count(EmptyCellCoveredAndMarked, BombCellCoveredAndMarked, BombCellRevealed),
+		CoveredCells: game.Board.Count(EmptyCellCovered, EmptyCellCoveredAndMarked, EmptyCellCoveredAndQuestioned,
+			BombCellCovered, BombCellCoveredAndMarked, BombCellCoveredAndQuestioned),
+	}
+
+	if empty := game.Board.CountEnabled() - game.Settings.BombsNumber; empty > 0 {
+		progress.RevealedPercentage = float64(game.Board.Count(EmptyCellRevealed)) * 100 / float64(empty)
+	}
+
+	switch {
+	case game.State == GameStateNew:
+	case game.IsFinished():
+		progress.ElapsedSeconds = int(game.EndedAt.Sub(game.StartedAt) / time.Second)
+	default:
+		progress.ElapsedSeconds = int(now.Sub(game.StartedAt) / time.Second)
+	}
+
+	return progress
 }
@@ -0,0 +1,163 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGame_IsFinished(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  bool
+	}{
+		{name: "new game", state: domain.GameStateNew, want: false},
+		{name: "ongoing game", state: domain.GameStateOnGoing, want: false},
+		{name: "lost game", state: domain.GameStateLost, want: true},
+		{name: "won game", state: domain.GameStateWon, want: true},
+		{name: "timed out game", state: domain.GameStateTimeout, want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := domain.Game{State: tt.state}.IsFinished()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGame_Topology(t *testing.T) {
+	assert.Equal(t, domain.StandardTopology, domain.Game{}.Topology())
+	assert.Equal(t, domain.TorusTopology, domain.Game{Settings: domain.GameSettings{Topology: domain.TopologyTorus}}.Topology())
+}
+
+func TestGame_Deadline(t *testing.T) {
+	startedAt := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	type want struct {
+		deadline time.Time
+		ok       bool
+	}
+
+	tests := []struct {
+		name string
+		game domain.Game
+		want want
+	}{
+		{
+			name: "ongoing game with time limit",
+			game: domain.Game{State: domain.GameStateOnGoing, StartedAt: startedAt, Settings: domain.GameSettings{TimeLimit: 90}},
+			want: want{deadline: startedAt.Add(90 * time.Second), ok: true},
+		},
+		{
+			name: "ongoing game without time limit",
+			game: domain.Game{State: domain.GameStateOnGoing, StartedAt: startedAt},
+			want: want{ok: false},
+		},
+		{
+			name: "new game with time limit",
+			game: domain.Game{State: domain.GameStateNew, Settings: domain.GameSettings{TimeLimit: 90}},
+			want: want{ok: false},
+		},
+		{
+			name: "finished game with time limit",
+			game: domain.Game{State: domain.GameStateWon, StartedAt: startedAt, Settings: domain.GameSettings{TimeLimit: 90}},
+			want: want{ok: false},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			deadline, ok := tt.game.Deadline()
+
+			assert.Equal(t, tt.want.deadline, deadline)
+			assert.Equal(t, tt.want.ok, ok)
+		})
+	}
+}
+
+func TestGame_Rate(t *testing.T) {
+	startedAt := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		game domain.Game
+		want domain.Performance
+	}{
+		{
+			name: "won game",
+			game: domain.Game{Moves: 10, Metrics: domain.BoardMetrics{ThreeBV: 8}, StartedAt: startedAt, EndedAt: startedAt.Add(4 * time.Second)},
+			want: domain.Performance{Efficiency: 0.8, ThreeBVPerSecond: 2},
+		},
+		{
+			name: "game won instantly",
+			game: domain.Game{Moves: 1, Metrics: domain.BoardMetrics{ThreeBV: 1}, StartedAt: startedAt, EndedAt: startedAt},
+			want: domain.Performance{Efficiency: 1},
+		},
+		{
+			name: "game without moves",
+			game: domain.Game{Metrics: domain.BoardMetrics{ThreeBV: 8}},
+			want: domain.Performance{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.game.Rate()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGame_Progress(t *testing.T) {
+	startedAt := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+	now := startedAt.Add(90 * time.Second)
+	settings := domain.GameSettings{Rows: 2, Columns: 5, BombsNumber: 3}
+
+	board := domain.Board{
+		{e, b, Y, B, E},
+		{X, Q, R, E, E},
+	}
+
+	tests := []struct {
+		name string
+		game domain.Game
+		want domain.Progress
+	}{
+		{
+			name: "new game",
+			game: domain.Game{State: domain.GameStateNew, Settings: settings, Board: domain.NewEmptyBoard(2, 5)},
+			want: domain.Progress{MinesRemaining: 3, CoveredCells: 10},
+		},
+		{
+			name: "ongoing game",
+			game: domain.Game{State: domain.GameStateOnGoing, Settings: settings, Board: board, StartedAt: startedAt},
+			want: domain.Progress{MinesRemaining: 0, CoveredCells: 6, RevealedPercentage: 3 * 100.0 / 7, ElapsedSeconds: 90},
+		},
+		{
+			name: "finished game",
+			game: domain.Game{State: domain.GameStateLost, Settings: settings, Board: board, StartedAt: startedAt, EndedAt: startedAt.Add(30 * time.Second)},
+			want: domain.Progress{MinesRemaining: 0, CoveredCells: 6, RevealedPercentage: 3 * 100.0 / 7, ElapsedSeconds: 30},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.game.Progress(now)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
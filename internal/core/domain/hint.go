@@ -0,0 +1,103 @@
+package domain
+
+import "fmt"
+
+const (
+	HintSafe  = "safe"
+	HintBomb  = "bomb"
+	HintGuess = "guess"
+)
+
+// Hint is a suggested move for the player together with the reasoning behind it
+type Hint struct {
+	Position    Position `json:"position"`
+	Kind        string   `json:"kind"`
+	Reason      string   `json:"reason"`
+	Probability float64  `json:"probability"`
+}
+
+// NewHint looks only at the information visible on the board and returns a cell that is proven safe, or else a bomb the
+// player has not flagged yet, or else the cell with the lowest estimated probability of having a bomb. The search for a
+// proof stops as soon as expired returns true, if given, and the estimate is returned instead.
+// Returns false if there is no covered cell left
+func NewHint(board Board, topology Topology, bombsNumber int, expired func() bool) (Hint, bool) {
+	solver := NewSolver(board, topology, bombsNumber)
+	solver.StopWhen(expired)
+
+	stopped := false
+	for {
+		if stopped = solver.isExpired(); stopped {
+			break
+		}
+
+		deductions := solver.Deduce()
+		if len(deductions) == 0 {
+			break
+		}
+
+		for _, deduction := range deductions {
+			if !deduction.Bomb {
+				return Hint{Position: deduction.Position, Kind: HintSafe, Reason: deduction.Reason, Probability: 0}, true
+			}
+		}
+
+		for _, deduction := range deductions {
+			if !board.Is(deduction.Position, EmptyCellCoveredAndMarked, BombCellCoveredAndMarked) {
+				return Hint{Position: deduction.Position, Kind: HintBomb, Reason: deduction.Reason, Probability: 1}, true
+			}
+		}
+
+		for _, deduction := range deductions {
+			solver.AddBomb(deduction.Position)
+		}
+	}
+
+	risks := solver.Risks()
+	if len(risks) == 0 {
+		return Hint{}, false
+	}
+
+	best := Hint{Kind: HintGuess, Probability: 2}
+	for _, pos := range solver.unknown() {
+		if risk := risks[pos]; risk < best.Probability {
+			best.Position, best.Probability = pos, risk
+		}
+	}
+
+	best.Reason = fmt.Sprintf("no cell can be proven safe, %s has the lowest estimated probability of having a bomb", best.Position)
+	if stopped {
+		best.Reason = fmt.Sprintf("there was no time to prove a cell safe, %s has the lowest estimated probability of having a bomb", best.Position)
+	}
+
+	return best, true
+}
+
+// Risks estimates, for every covered cell not known to have a bomb, the probability of having a bomb.
+// Cells next to revealed cells take the highest ratio of missing bombs per covered neighbor among those revealed cells,
+// the rest take the ratio of the bombs left per covered cell on the board
+func (solver *Solver) Risks() map[Position]float64 {
+	unknown := solver.unknown()
+	risks := make(map[Position]float64, len(unknown))
+	if len(unknown) == 0 {
+		return risks
+	}
+
+	density := float64(solver.RemainingBombs()) / float64(len(unknown))
+	for _, pos := range unknown {
+		risks[pos] = density
+	}
+
+	bordered := map[Position]bool{}
+	for _, c := range solver.constraints() {
+		ratio := float64(c.bombs) / float64(len(c.cells))
+		for _, pos := range c.cells {
+			if !bordered[pos] || ratio > risks[pos] {
+				risks[pos] = ratio
+			}
+
+			bordered[pos] = true
+		}
+	}
+
+	return risks
+}
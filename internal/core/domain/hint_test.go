@@ -0,0 +1,109 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewHint(t *testing.T) {
+	X := domain.EmptyCellCoveredAndMarked
+
+	type args struct {
+		board       domain.Board
+		bombsNumber int
+		expired     func() bool
+	}
+
+	type want struct {
+		position    domain.Position
+		kind        string
+		probability float64
+		ok          bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "safe cell",
+			args: args{board: domain.Board{
+				{e, E, E},
+				{b, E, E},
+				{e, E, E},
+			}, bombsNumber: 1},
+			want: want{position: domain.NewPosition(2, 0), kind: domain.HintSafe, probability: 0, ok: true},
+		},
+		{
+			name: "bomb not flagged yet",
+			args: args{board: domain.Board{
+				{b, E, E},
+				{E, E, E},
+			}, bombsNumber: 1},
+			want: want{position: domain.NewPosition(0, 0), kind: domain.HintBomb, probability: 1, ok: true},
+		},
+		{
+			name: "safe cell found after the flagged bombs",
+			args: args{board: domain.Board{
+				{Y, E, e},
+				{E, E, e},
+			}, bombsNumber: 1},
+			want: want{position: domain.NewPosition(0, 2), kind: domain.HintSafe, probability: 0, ok: true},
+		},
+		{
+			name: "wrong flag proven safe",
+			args: args{board: domain.Board{
+				{X, E, E},
+				{E, E, E},
+				{E, E, b},
+			}, bombsNumber: 1},
+			want: want{position: domain.NewPosition(0, 0), kind: domain.HintSafe, probability: 0, ok: true},
+		},
+		{
+			name: "lowest risk guess",
+			args: args{board: domain.Board{
+				{e, e, e, e, e, e},
+				{e, b, e, e, e, e},
+				{E, e, e, e, e, b},
+			}, bombsNumber: 2},
+			want: want{position: domain.NewPosition(0, 0), kind: domain.HintGuess, probability: 2.0 / 17.0, ok: true},
+		},
+		{
+			name: "lowest risk guess when the time to prove a cell safe runs out",
+			args: args{board: domain.Board{
+				{e, E, E},
+				{b, E, E},
+				{e, E, E},
+			}, bombsNumber: 1, expired: func() bool { return true }},
+			want: want{position: domain.NewPosition(0, 0), kind: domain.HintGuess, probability: 0.5, ok: true},
+		},
+		{
+			name: "no covered cells left",
+			args: args{board: domain.Board{
+				{E, E},
+				{E, B},
+			}, bombsNumber: 1},
+			want: want{ok: false},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			hint, ok := domain.NewHint(tt.args.board, domain.StandardTopology, tt.args.bombsNumber, tt.args.expired)
+
+			assert.Equal(t, tt.want.ok, ok)
+			if !tt.want.ok {
+				return
+			}
+
+			assert.Equal(t, tt.want.position, hint.Position)
+			assert.Equal(t, tt.want.kind, hint.Kind)
+			assert.InDelta(t, tt.want.probability, hint.Probability, 1e-9)
+			assert.NotEmpty(t, hint.Reason)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// Snapshot is the state of a game before a move, kept so the move can be undone
+type Snapshot struct {
+	Board          Board        `json:"board"`
+	State          string       `json:"state"`
+	RemainingLives int          `json:"remaining_lives"`
+	Metrics        BoardMetrics `json:"metrics"`
+	StartedAt      time.Time    `json:"started_at"`
+	EndedAt        time.Time    `json:"ended_at"`
+	Moves          int          `json:"moves"`
+	HintsUsed      int          `json:"hints_used"`
+}
+
+// Remember keeps the current state of the game so the next move can be undone. Only the last limit states are kept
+func (game *Game) Remember(limit int) {
+	game.History = append(game.History, Snapshot{
+		Board:          game.Board.Copy(),
+		State:          game.State,
+		RemainingLives: game.RemainingLives,
+		Metrics:        game.Metrics,
+		StartedAt:      game.StartedAt,
+		EndedAt:        game.EndedAt,
+		Moves:          game.Moves,
+		HintsUsed:      game.HintsUsed,
+	})
+
+	if len(game.History) > limit {
+		game.History = game.History[len(game.History)-limit:]
+	}
+}
+
+// Undo restores the game to the state it had before the last move and counts it; returns false if there is nothing to undo
+func (game *Game) Undo() bool {
+	if len(game.History) == 0 {
+		return false
+	}
+
+	last := game.History[len(game.History)-1]
+	game.History = game.History[:len(game.History)-1]
+	if len(game.History) == 0 {
+		game.History = nil
+	}
+
+	game.Board = last.Board
+	game.State = last.State
+	game.RemainingLives = last.RemainingLives
+	game.Metrics = last.Metrics
+	game.StartedAt = last.StartedAt
+	game.EndedAt = last.EndedAt
+	game.Moves = last.Moves
+	game.HintsUsed = last.HintsUsed
+	game.Performance = Performance{}
+	game.Undos++
+
+	return true
+}
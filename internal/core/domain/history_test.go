@@ -0,0 +1,59 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGame_Remember(t *testing.T) {
+	// Setup
+	game := domain.Game{Board: domain.Board{{e, b}}, State: domain.GameStateOnGoing, RemainingLives: 1}
+
+	// Execute
+	game.Remember(2)
+	game.Board.Set(domain.NewPosition(0, 0), E)
+	game.Moves++
+	game.Remember(2)
+	game.Board.Set(domain.NewPosition(0, 1), Y)
+	game.Moves++
+	game.HintsUsed++
+	game.Remember(2)
+
+	// Verify
+	assert.Equal(t, []domain.Snapshot{
+		{Board: domain.Board{{E, b}}, State: domain.GameStateOnGoing, RemainingLives: 1, Moves: 1},
+		{Board: domain.Board{{E, Y}}, State: domain.GameStateOnGoing, RemainingLives: 1, Moves: 2, HintsUsed: 1},
+	}, game.History)
+}
+
+func TestGame_Undo(t *testing.T) {
+	startedAt := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	// Setup
+	game := domain.Game{Board: domain.Board{{E, b}}, State: domain.GameStateOnGoing, RemainingLives: 1, StartedAt: startedAt, Moves: 1}
+	game.Remember(10)
+	game.HintsUsed++
+	game.Board.Set(domain.NewPosition(0, 1), B)
+	game.Moves++
+	game.State = domain.GameStateLost
+	game.RemainingLives = 0
+	game.EndedAt = startedAt.Add(time.Minute)
+
+	// Execute
+	undone := game.Undo()
+	undoneAgain := game.Undo()
+
+	// Verify
+	assert.True(t, undone)
+	assert.False(t, undoneAgain)
+	assert.Equal(t, domain.Game{
+		Board:          domain.Board{{E, b}},
+		State:          domain.GameStateOnGoing,
+		RemainingLives: 1,
+		StartedAt:      startedAt,
+		Moves:          1,
+		Undos:          1,
+	}, game)
+}
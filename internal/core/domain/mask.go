@@ -0,0 +1,47 @@
+package domain
+
+const (
+	// DisabledCell is a cell left out of the board by its mask: it is never mined nor revealed and counts as no neighbor
+	DisabledCell = Cell('D')
+
+	MaskLayoutEnabled  = '#'
+	MaskLayoutDisabled = '.'
+)
+
+// Mask shapes the board disabling some of its cells, either listing them or drawing the whole board as ASCII art,
+// one string per row where MaskLayoutEnabled is a cell of the board and MaskLayoutDisabled is a disabled one.
+// Both can be used at once
+type Mask struct {
+	Cells  []Position `json:"cells,omitempty"`
+	Layout []string   `json:"layout,omitempty"`
+}
+
+// Disabled returns the positions disabled by the mask, as listed and as drawn in the layout
+func (mask Mask) Disabled() []Position {
+	positions := append([]Position{}, mask.Cells...)
+	for row, line := range mask.Layout {
+		for column, c := range []rune(line) {
+			if c == MaskLayoutDisabled {
+				positions = append(positions, NewPosition(row, column))
+			}
+		}
+	}
+
+	return positions
+}
+
+// Disable leaves the given positions out of the board
+func (board Board) Disable(positions []Position) {
+	for _, pos := range positions {
+		board.Set(pos, DisabledCell)
+	}
+}
+
+// CountEnabled counts the cells of the board that have not been disabled by a mask
+func (board Board) CountEnabled() int {
+	if len(board) == 0 {
+		return 0
+	}
+
+	return len(board)*len(board[0]) - board.Count(DisabledCell)
+}
@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var D = domain.DisabledCell
+
+func TestMask_Disabled(t *testing.T) {
+	mask := domain.Mask{
+		Cells: []domain.Position{{Row: 0, Column: 1}},
+		Layout: []string{
+			"###.",
+			".##.",
+		},
+	}
+
+	assert.Equal(t, []domain.Position{{Row: 0, Column: 1}, {Row: 0, Column: 3}, {Row: 1, Column: 0}, {Row: 1, Column: 3}}, mask.Disabled())
+	assert.Empty(t, domain.Mask{}.Disabled())
+}
+
+func TestBoard_Disable(t *testing.T) {
+	// Setup
+	board := domain.NewEmptyBoard(2, 3)
+
+	// Execute
+	board.Disable([]domain.Position{{Row: 0, Column: 0}, {Row: 1, Column: 2}})
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{D, e, e},
+		{e, e, D},
+	}, board)
+	assert.Equal(t, 4, board.CountEnabled())
+	assert.False(t, board.IsRevealable(domain.NewPosition(0, 0)))
+	assert.False(t, board.IsCovered(domain.NewPosition(0, 0)))
+	assert.False(t, board.HasBomb(domain.NewPosition(0, 0)))
+}
+
+func TestBoard_RevealInCascade_WithDisabledCells(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, e, D, e, e},
+		{e, e, D, e, e},
+		{D, D, D, e, b},
+	}
+
+	// Execute
+	revealed := board.RevealInCascade(domain.StandardTopology, domain.NewPosition(0, 0))
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{E, E, D, e, e},
+		{E, E, D, e, e},
+		{D, D, D, e, b},
+	}, board)
+	assert.Len(t, revealed, 4)
+}
+
+func TestBoard_Metrics_WithDisabledCells(t *testing.T) {
+	board := domain.Board{
+		{e, e, D, e, e},
+		{e, e, D, e, e},
+		{D, D, D, e, b},
+	}
+
+	assert.Equal(t, domain.BoardMetrics{ThreeBV: 3, Openings: 2, Islands: 1}, board.Metrics(domain.StandardTopology))
+}
@@ -0,0 +1,128 @@
+package domain
+
+import "time"
+
+const (
+	MatchStateOnGoing  = "ongoing"
+	MatchStateFinished = "finished"
+)
+
+// MatchRequest lists the players that race in a match and the settings of the board they all play
+type MatchRequest struct {
+	UserIDs  []string     `json:"user_ids"`
+	Settings GameSettings `json:"settings"`
+}
+
+// Match is a race between players on identical boards. The first one to win the game, or the last one left playing
+// when all the others have lost, is the winner
+type Match struct {
+	ID           string             `json:"id"`
+	Settings     GameSettings       `json:"settings"`
+	Participants []MatchParticipant `json:"participants"`
+	State        string             `json:"state"`
+	WinnerID     string             `json:"winner_id"`
+	Version      int                `json:"version"`
+	CreatedAt    time.Time          `json:"created_at"`
+	EndedAt      time.Time          `json:"ended_at"`
+}
+
+// MatchParticipant is a player of a match and how far its game has gone
+type MatchParticipant struct {
+	UserID             string  `json:"user_id"`
+	GameID             string  `json:"game_id"`
+	State              string  `json:"state"`
+	Moves              int     `json:"moves"`
+	RevealedPercentage float64 `json:"revealed_percentage"`
+}
+
+// NewMatch returns an ongoing match between the players of the given games
+func NewMatch(id string, games []Game, now time.Time) Match {
+	match := Match{
+		ID:           id,
+		Participants: make([]MatchParticipant, len(games)),
+		State:        MatchStateOnGoing,
+		CreatedAt:    now,
+	}
+
+	if len(games) > 0 {
+		match.Settings = games[0].Settings
+	}
+
+	for i, game := range games {
+		match.Participants[i] = MatchParticipant{UserID: game.UserID, GameID: game.ID, State: game.State}
+	}
+
+	return match
+}
+
+// HasParticipant returns true if the given user is one of the players of the match
+func (match Match) HasParticipant(userID string) bool {
+	for _, participant := range match.Participants {
+		if participant.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Update records how far the given game of a participant has gone and declares the winner as soon as it is decided.
+// Once the match has finished the games can still be played, but the winner does not change. Returns false if the
+// game does not belong to the match
+func (match *Match) Update(game Game) bool {
+	i := match.indexOf(game.ID)
+	if i < 0 {
+		return false
+	}
+
+	match.Participants[i].State = game.State
+	match.Participants[i].Moves = game.Moves
+	match.Participants[i].RevealedPercentage = game.Progress(game.EndedAt).RevealedPercentage
+
+	if match.State == MatchStateFinished {
+		return true
+	}
+
+	if game.State == GameStateWon {
+		match.finish(game.UserID, game.EndedAt)
+		return true
+	}
+
+	playing := -1
+	for j, participant := range match.Participants {
+		if participant.State == GameStateLost || participant.State == GameStateTimeout {
+			continue
+		}
+
+		if playing >= 0 {
+			return true
+		}
+
+		playing = j
+	}
+
+	if playing < 0 {
+		match.finish("", game.EndedAt)
+		return true
+	}
+
+	match.finish(match.Participants[playing].UserID, game.EndedAt)
+
+	return true
+}
+
+func (match Match) indexOf(gameID string) int {
+	for i, participant := range match.Participants {
+		if participant.GameID == gameID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (match *Match) finish(winnerID string, endedAt time.Time) {
+	match.State = MatchStateFinished
+	match.WinnerID = winnerID
+	match.EndedAt = endedAt
+}
@@ -0,0 +1,154 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewMatch(t *testing.T) {
+	// Setup
+	now := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+	settings := domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1}
+	games := []domain.Game{
+		{ID: "g1", UserID: "111", Settings: settings, State: domain.GameStateNew},
+		{ID: "g2", UserID: "222", Settings: settings, State: domain.GameStateNew},
+	}
+
+	// Execute
+	match := domain.NewMatch("abc", games, now)
+
+	// Verify
+	assert.Equal(t, domain.Match{
+		ID:       "abc",
+		Settings: settings,
+		Participants: []domain.MatchParticipant{
+			{UserID: "111", GameID: "g1", State: domain.GameStateNew},
+			{UserID: "222", GameID: "g2", State: domain.GameStateNew},
+		},
+		State:     domain.MatchStateOnGoing,
+		CreatedAt: now,
+	}, match)
+	assert.True(t, match.HasParticipant("222"))
+	assert.False(t, match.HasParticipant("333"))
+}
+
+func TestMatch_Update(t *testing.T) {
+	endedAt := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+	userIDs := []string{"1", "2", "3"}
+
+	newMatch := func(states ...string) domain.Match {
+		match := domain.Match{ID: "abc", State: domain.MatchStateOnGoing}
+		for i, state := range states {
+			match.Participants = append(match.Participants, domain.MatchParticipant{
+				UserID: userIDs[i],
+				GameID: "g" + userIDs[i],
+				State:  state,
+			})
+		}
+
+		return match
+	}
+
+	game := func(userID string, state string) domain.Game {
+		return domain.Game{
+			ID:       "g" + userID,
+			UserID:   userID,
+			Board:    domain.Board{{E, b}, {E, e}},
+			Settings: domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1},
+			State:    state,
+			Moves:    2,
+			EndedAt:  endedAt,
+		}
+	}
+
+	type want struct {
+		ok       bool
+		state    string
+		winnerID string
+	}
+
+	tests := []struct {
+		name  string
+		match domain.Match
+		game  domain.Game
+		want  want
+	}{
+		{
+			name:  "the progress of an ongoing game is recorded",
+			match: newMatch(domain.GameStateNew, domain.GameStateNew, domain.GameStateNew),
+			game:  game("2", domain.GameStateOnGoing),
+			want:  want{ok: true, state: domain.MatchStateOnGoing},
+		},
+		{
+			name:  "the first player to win is the winner",
+			match: newMatch(domain.GameStateOnGoing, domain.GameStateOnGoing, domain.GameStateOnGoing),
+			game:  game("2", domain.GameStateWon),
+			want:  want{ok: true, state: domain.MatchStateFinished, winnerID: "2"},
+		},
+		{
+			name:  "the match goes on while several players are left",
+			match: newMatch(domain.GameStateOnGoing, domain.GameStateOnGoing, domain.GameStateOnGoing),
+			game:  game("1", domain.GameStateLost),
+			want:  want{ok: true, state: domain.MatchStateOnGoing},
+		},
+		{
+			name:  "the last player left is the winner",
+			match: newMatch(domain.GameStateTimeout, domain.GameStateOnGoing, domain.GameStateNew),
+			game:  game("2", domain.GameStateLost),
+			want:  want{ok: true, state: domain.MatchStateFinished, winnerID: "3"},
+		},
+		{
+			name:  "there is no winner when every player has lost",
+			match: newMatch(domain.GameStateLost),
+			game:  game("1", domain.GameStateLost),
+			want:  want{ok: true, state: domain.MatchStateFinished},
+		},
+		{
+			name: "the winner does not change once the match has finished",
+			match: func() domain.Match {
+				match := newMatch(domain.GameStateWon, domain.GameStateOnGoing)
+				match.State, match.WinnerID = domain.MatchStateFinished, "1"
+				return match
+			}(),
+			game: game("2", domain.GameStateWon),
+			want: want{ok: true, state: domain.MatchStateFinished, winnerID: "1"},
+		},
+		{
+			name:  "games of other matches are ignored",
+			match: newMatch(domain.GameStateOnGoing, domain.GameStateOnGoing),
+			game:  game("9", domain.GameStateWon),
+			want:  want{ok: false, state: domain.MatchStateOnGoing},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ok := tt.match.Update(tt.game)
+
+			assert.Equal(t, tt.want.ok, ok)
+			assert.Equal(t, tt.want.state, tt.match.State)
+			assert.Equal(t, tt.want.winnerID, tt.match.WinnerID)
+
+			if ok {
+				participant := tt.match.Participants[indexOf(userIDs, tt.game.UserID)]
+				assert.Equal(t, tt.game.State, participant.State)
+				assert.Equal(t, 2, participant.Moves)
+				assert.Equal(t, float64(100)*2/3, participant.RevealedPercentage)
+			}
+		})
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i := range values {
+		if values[i] == value {
+			return i
+		}
+	}
+
+	return -1
+}
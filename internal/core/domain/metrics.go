@@ -0,0 +1,104 @@
+package domain
+
+// BoardMetrics rates how hard a board is, no matter how it is played
+type BoardMetrics struct {
+	ThreeBV  int `json:"3bv"`
+	Openings int `json:"openings"`
+	Islands  int `json:"islands"`
+}
+
+// Metrics computes the metrics of the board looking at where its bombs are, no matter which cells have been revealed.
+// An opening is a region of cells without bombs around, which is revealed with a single click together with its border.
+// An island is a group of adjacent cells with bombs around that are not in the border of any opening, so every one of
+// them needs its own click. The 3BV (Bechtel's Board Benchmark Value) is the minimum number of clicks to win the game:
+// one per opening plus one per cell that is neither in an opening nor in its border
+func (board Board) Metrics(topology Topology) BoardMetrics {
+	var metrics BoardMetrics
+	var pos Position
+
+	zeros := board.zeros(topology)
+	opened := newBitmap(board)
+	for row := range board {
+		for column := range board[0] {
+			pos = NewPosition(row, column)
+			if opened.has(pos) || !zeros.has(pos) {
+				continue
+			}
+
+			metrics.Openings++
+			board.flood(topology, pos, opened, zeros.has)
+		}
+	}
+
+	isolated := func(current Position) bool {
+		return !opened.has(current) && !board.HasBomb(current) && !board.Is(current, DisabledCell)
+	}
+
+	visited := newBitmap(board)
+	for row := range board {
+		for column := range board[0] {
+			pos = NewPosition(row, column)
+			if visited.has(pos) || !isolated(pos) {
+				continue
+			}
+
+			metrics.Islands++
+			metrics.ThreeBV += board.flood(topology, pos, visited, isolated)
+		}
+	}
+
+	metrics.ThreeBV += metrics.Openings
+
+	return metrics
+}
+
+// zeros returns the positions that have no bomb and no bombs around
+func (board Board) zeros(topology Topology) bitmap {
+	var neighbors []Position
+	var pos Position
+
+	zeros := newBitmap(board)
+	for row := range board {
+		for column := range board[0] {
+			pos = NewPosition(row, column)
+			if board.HasBomb(pos) || board.Is(pos, DisabledCell) {
+				continue
+			}
+
+			neighbors = topology.AppendNeighbors(neighbors[:0], board, pos)
+			if !board.anyBomb(neighbors) {
+				zeros.add(pos)
+			}
+		}
+	}
+
+	return zeros
+}
+
+// flood visits the cells connected to start that satisfy expand, as well as their neighbors, and returns how many of
+// the visited cells satisfy expand
+func (board Board) flood(topology Topology, start Position, visited bitmap, expand func(Position) bool) int {
+	var neighbors []Position
+	count := 0
+	queue := []Position{start}
+	visited.add(start)
+
+	for head := 0; head < len(queue); head++ {
+		pos := queue[head]
+
+		if !expand(pos) {
+			continue
+		}
+
+		count++
+		neighbors = topology.AppendNeighbors(neighbors[:0], board, pos)
+		for _, neighbor := range neighbors {
+			if !visited.has(neighbor) {
+				visited.add(neighbor)
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return count
+}
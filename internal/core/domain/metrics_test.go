@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBoard_Metrics(t *testing.T) {
+	tests := []struct {
+		name  string
+		board domain.Board
+		want  domain.BoardMetrics
+	}{
+		{
+			name: "board without bombs",
+			board: domain.Board{
+				{e, e, e},
+				{e, e, e},
+			},
+			want: domain.BoardMetrics{ThreeBV: 1, Openings: 1, Islands: 0},
+		},
+		{
+			name: "board without openings",
+			board: domain.Board{
+				{e, e, e},
+				{e, b, e},
+				{e, e, e},
+			},
+			want: domain.BoardMetrics{ThreeBV: 8, Openings: 0, Islands: 1},
+		},
+		{
+			name: "board with openings and islands",
+			board: domain.Board{
+				{b, e, b, e, e, e},
+				{e, b, e, e, e, e},
+				{e, e, e, e, b, e},
+				{e, e, e, e, e, b},
+			},
+			want: domain.BoardMetrics{ThreeBV: 7, Openings: 2, Islands: 2},
+		},
+		{
+			name: "revealed and marked cells are rated the same as covered ones",
+			board: domain.Board{
+				{B, E, Y, E, E, E},
+				{E, b, E, E, E, E},
+				{E, E, E, E, b, E},
+				{E, E, E, E, E, b},
+			},
+			want: domain.BoardMetrics{ThreeBV: 7, Openings: 2, Islands: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.board.Metrics(domain.StandardTopology)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
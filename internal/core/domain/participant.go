@@ -0,0 +1,38 @@
+package domain
+
+// ActionRecord is an action applied to a shared game and the participant that made it. Move is the number of moves
+// of the game once the action was applied, which orders the records
+type ActionRecord struct {
+	UserID string `json:"user_id"`
+	Action
+	Move int `json:"move"`
+}
+
+// IsShared returns true if the game is played by other users besides its owner
+func (game Game) IsShared() bool {
+	return len(game.Participants) > 0
+}
+
+// IsParticipant returns true if the given user can play the game, either as its owner or as one of its participants
+func (game Game) IsParticipant(userID string) bool {
+	if game.UserID == userID {
+		return true
+	}
+
+	for _, participant := range game.Participants {
+		if participant == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Record keeps who made the given action. Only the last limit records are kept
+func (game *Game) Record(userID string, action Action, limit int) {
+	game.Log = append(game.Log, ActionRecord{UserID: userID, Action: action, Move: game.Moves})
+
+	if len(game.Log) > limit {
+		game.Log = game.Log[len(game.Log)-limit:]
+	}
+}
@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGame_IsParticipant(t *testing.T) {
+	game := domain.Game{UserID: "111", Participants: []string{"222", "333"}}
+
+	assert.True(t, game.IsShared())
+	assert.True(t, game.IsParticipant("111"))
+	assert.True(t, game.IsParticipant("333"))
+	assert.False(t, game.IsParticipant("444"))
+	assert.False(t, domain.Game{UserID: "111"}.IsShared())
+}
+
+func TestGame_Record(t *testing.T) {
+	// Setup
+	game := domain.Game{UserID: "111", Participants: []string{"222"}}
+
+	// Execute
+	for i := 0; i < 3; i++ {
+		game.Moves++
+		game.Record("222", domain.Action{Type: domain.ActionReveal, Coordinates: domain.NewOffsetCoordinates(i, i)}, 2)
+	}
+
+	// Verify
+	assert.Equal(t, []domain.ActionRecord{
+		{UserID: "222", Action: domain.Action{Type: domain.ActionReveal, Coordinates: domain.NewOffsetCoordinates(1, 1)}, Move: 2},
+		{UserID: "222", Action: domain.Action{Type: domain.ActionReveal, Coordinates: domain.NewOffsetCoordinates(2, 2)}, Move: 3},
+	}, game.Log)
+}
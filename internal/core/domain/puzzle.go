@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// PuzzleLayoutMine marks a cell with a mine in the layout of a puzzle, where MaskLayoutEnabled is a cell without mine
+// and MaskLayoutDisabled a disabled one
+const PuzzleLayoutMine = '*'
+
+// PuzzleDesign is a board drawn by a player to be shared: the layout has a string per row with a character per cell.
+// The author is the authenticated user, never taken from the body of the request
+type PuzzleDesign struct {
+	AuthorID string   `json:"-"`
+	Name     string   `json:"name"`
+	Topology string   `json:"topology"`
+	Layout   []string `json:"layout"`
+}
+
+// Puzzle is a board with its mines fixed, so every game created from it is played on the same board
+type Puzzle struct {
+	ID          string    `json:"id"`
+	AuthorID    string    `json:"author_id"`
+	Name        string    `json:"name"`
+	Topology    string    `json:"topology"`
+	Rows        int       `json:"rows"`
+	Columns     int       `json:"columns"`
+	BombsNumber int       `json:"bombs_number"`
+	Board       Board     `json:"board"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// LeaderboardEntry is the best game won by a player in a puzzle
+type LeaderboardEntry struct {
+	Rank    int       `json:"rank"`
+	UserID  string    `json:"user_id"`
+	GameID  string    `json:"game_id"`
+	Seconds float64   `json:"seconds"`
+	Moves   int       `json:"moves"`
+	EndedAt time.Time `json:"ended_at"`
+}
+
+// NewPuzzleBoard returns the covered board drawn in the given layout, with its mines placed. It assumes the layout
+// has a valid character per cell and the same number of characters in every row
+func NewPuzzleBoard(layout []string) Board {
+	board := NewEmptyBoard(len(layout), len([]rune(layout[0])))
+	for row, line := range layout {
+		for column, c := range []rune(line) {
+			switch c {
+			case PuzzleLayoutMine:
+				board.Set(NewPosition(row, column), BombCellCovered)
+			case MaskLayoutDisabled:
+				board.Set(NewPosition(row, column), DisabledCell)
+			}
+		}
+	}
+
+	return board
+}
+
+// IsRankedIn returns true if the game takes part in the leaderboard of the given puzzle: it must have been played
+// fairly, as any ranked game, and not by the author of the puzzle, who knows where the mines are
+func (game Game) IsRankedIn(puzzle Puzzle) bool {
+	return game.Settings.PuzzleID == puzzle.ID && game.isPlayedFairly() && game.UserID != puzzle.AuthorID
+}
+
+// NewLeaderboard ranks the players by the fastest game they have won in the puzzle, breaking ties by fewer moves and
+// then by who finished first. Only the best size players are returned
+func NewLeaderboard(puzzle Puzzle, games []Game, size int) []LeaderboardEntry {
+	best := map[string]LeaderboardEntry{}
+	for _, game := range games {
+		if game.State != GameStateWon || !game.IsRankedIn(puzzle) {
+			continue
+		}
+
+		entry := LeaderboardEntry{
+			UserID:  game.UserID,
+			GameID:  game.ID,
+			Seconds: game.EndedAt.Sub(game.StartedAt).Seconds(),
+			Moves:   game.Moves,
+			EndedAt: game.EndedAt,
+		}
+
+		if current, ok := best[game.UserID]; !ok || entry.isBetterThan(current) {
+			best[game.UserID] = entry
+		}
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(best))
+	for _, entry := range best {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].isBetterThan(entries[j])
+	})
+
+	if len(entries) > size {
+		entries = entries[:size]
+	}
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	return entries
+}
+
+func (entry LeaderboardEntry) isBetterThan(other LeaderboardEntry) bool {
+	switch {
+	case entry.Seconds != other.Seconds:
+		return entry.Seconds < other.Seconds
+	case entry.Moves != other.Moves:
+		return entry.Moves < other.Moves
+	case !entry.EndedAt.Equal(other.EndedAt):
+		return entry.EndedAt.Before(other.EndedAt)
+	default:
+		return entry.GameID < other.GameID
+	}
+}
@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPuzzleBoard(t *testing.T) {
+	// Execute
+	board := domain.NewPuzzleBoard([]string{
+		"*#.",
+		"##*",
+	})
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{b, e, D},
+		{e, e, b},
+	}, board)
+}
+
+func TestGame_IsRankedIn(t *testing.T) {
+	puzzle := domain.Puzzle{ID: "abc", AuthorID: "222"}
+
+	assert.True(t, domain.Game{UserID: "111", Settings: domain.GameSettings{PuzzleID: "abc"}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "111", Settings: domain.GameSettings{PuzzleID: "xyz"}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "111", Settings: domain.GameSettings{PuzzleID: "abc", Practice: true}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "111", SourceGameID: "xyz", Settings: domain.GameSettings{PuzzleID: "abc"}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "222", Settings: domain.GameSettings{PuzzleID: "abc"}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "111", MatchID: "xyz", Settings: domain.GameSettings{PuzzleID: "abc"}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "111", Participants: []string{"333"}, Settings: domain.GameSettings{PuzzleID: "abc"}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "111", Settings: domain.GameSettings{PuzzleID: "abc", Lives: 3}}.IsRankedIn(puzzle))
+	assert.True(t, domain.Game{UserID: "111", Settings: domain.GameSettings{PuzzleID: "abc", Lives: 1}}.IsRankedIn(puzzle))
+	assert.False(t, domain.Game{UserID: "111", HintsUsed: 2, Settings: domain.GameSettings{PuzzleID: "abc"}}.IsRankedIn(puzzle))
+}
+
+func TestNewLeaderboard(t *testing.T) {
+	// Setup
+	startedAt := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+	puzzle := domain.Puzzle{ID: "abc", AuthorID: "222"}
+
+	game := func(userID string, gameID string, state string, seconds int, moves int) domain.Game {
+		return domain.Game{
+			ID:        gameID,
+			UserID:    userID,
+			Settings:  domain.GameSettings{PuzzleID: "abc"},
+			State:     state,
+			Moves:     moves,
+			StartedAt: startedAt,
+			EndedAt:   startedAt.Add(time.Duration(seconds) * time.Second),
+		}
+	}
+
+	games := []domain.Game{
+		game("111", "g1", domain.GameStateWon, 40, 8),
+		game("111", "g2", domain.GameStateWon, 30, 9),
+		game("333", "g3", domain.GameStateWon, 30, 7),
+		game("444", "g4", domain.GameStateLost, 5, 1),
+		game("222", "g5", domain.GameStateWon, 5, 1),
+		game("555", "g6", domain.GameStateWon, 50, 10),
+	}
+
+	// Execute
+	leaderboard := domain.NewLeaderboard(puzzle, games, 2)
+
+	// Verify
+	assert.Equal(t, []domain.LeaderboardEntry{
+		{Rank: 1, UserID: "333", GameID: "g3", Seconds: 30, Moves: 7, EndedAt: startedAt.Add(30 * time.Second)},
+		{Rank: 2, UserID: "111", GameID: "g2", Seconds: 30, Moves: 9, EndedAt: startedAt.Add(30 * time.Second)},
+	}, leaderboard)
+}
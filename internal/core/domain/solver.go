@@ -0,0 +1,261 @@
+package domain
+
+import "fmt"
+
+// Deduction is a fact about a covered cell that can be proven from the information visible to the player
+type Deduction struct {
+	Position Position `json:"position"`
+	Bomb     bool     `json:"bomb"`
+	Reason   string   `json:"reason"`
+}
+
+// Solver deduces safe cells and bombs looking only at the revealed cells of a board and the total number of bombs.
+// Covered cells are never inspected, so a solver can be used over the board of an ongoing game
+type Solver struct {
+	board       Board
+	topology    Topology
+	bombsNumber int
+	bombs       map[Position]bool
+	expired     func() bool
+}
+
+// constraint states that exactly bombs of the given cells have a bomb, as shown by the revealed cell in center
+type constraint struct {
+	center Position
+	cells  []Position
+	bombs  int
+}
+
+func NewSolver(board Board, topology Topology, bombsNumber int) *Solver {
+	return &Solver{board: board, topology: topology, bombsNumber: bombsNumber, bombs: map[Position]bool{}}
+}
+
+// StopWhen makes the solver give up deducing as soon as expired returns true, returning what it has found so far
+func (solver *Solver) StopWhen(expired func() bool) {
+	solver.expired = expired
+}
+
+// AddBomb records a covered cell that is known to have a bomb
+func (solver *Solver) AddBomb(pos Position) {
+	solver.bombs[pos] = true
+}
+
+// Deduce returns the covered cells that can be proven safe or with a bomb at this point.
+// It returns empty if it is not possible to progress without guessing
+func (solver *Solver) Deduce() []Deduction {
+	constraints := solver.constraints()
+	found := map[Position]bool{}
+	var deductions []Deduction
+
+	add := func(cells []Position, bomb bool, reason string) {
+		for _, cell := range cells {
+			if found[cell] {
+				continue
+			}
+
+			found[cell] = true
+			deductions = append(deductions, Deduction{Position: cell, Bomb: bomb, Reason: reason})
+		}
+	}
+
+	for _, c := range constraints {
+		switch c.bombs {
+		case 0:
+			add(c.cells, false, fmt.Sprintf("all the bombs around %s have already been found", c.center))
+		case len(c.cells):
+			add(c.cells, true, fmt.Sprintf("%s needs %d more bombs and has only %d covered neighbors", c.center, c.bombs, len(c.cells)))
+		}
+	}
+
+	if len(deductions) > 0 {
+		return deductions
+	}
+
+	// a constraint can only be a subset of the constraints that cover its first cell, so only those are compared
+	covering := map[Position][]int{}
+	for i, c := range constraints {
+		for _, cell := range c.cells {
+			covering[cell] = append(covering[cell], i)
+		}
+	}
+
+	for i, a := range constraints {
+		if solver.isExpired() {
+			return deductions
+		}
+
+		for _, j := range covering[a.cells[0]] {
+			b := constraints[j]
+			if i == j || !isSubset(a.cells, b.cells) {
+				continue
+			}
+
+			diff := difference(b.cells, a.cells)
+			if len(diff) == 0 {
+				continue
+			}
+
+			switch b.bombs - a.bombs {
+			case 0:
+				add(diff, false, fmt.Sprintf("the bombs around %s are all shared with %s, so its other neighbors are safe", b.center, a.center))
+			case len(diff):
+				add(diff, true, fmt.Sprintf("%s needs %d bombs more than %s and only %d of its neighbors are not shared", b.center, b.bombs-a.bombs, a.center, len(diff)))
+			}
+		}
+	}
+
+	if len(deductions) > 0 {
+		return deductions
+	}
+
+	unknown := solver.unknown()
+	remaining := solver.RemainingBombs()
+
+	switch {
+	case len(unknown) == 0:
+	case remaining == 0:
+		add(unknown, false, "all the bombs of the board have already been found")
+	case remaining == len(unknown):
+		add(unknown, true, fmt.Sprintf("there are %d bombs left and only %d covered cells", remaining, len(unknown)))
+	}
+
+	return deductions
+}
+
+// RemainingBombs returns the number of bombs that are neither known by the solver nor exploded
+func (solver *Solver) RemainingBombs() int {
+	return solver.bombsNumber - len(solver.bombs) - solver.board.Count(BombCellRevealed)
+}
+
+// constraints returns, for every revealed cell touching covered cells, the unknown cells around it and how many of them have a bomb
+func (solver *Solver) constraints() []constraint {
+	var constraints []constraint
+	var pos Position
+
+	for row := range solver.board {
+		for column := range solver.board[0] {
+			pos = NewPosition(row, column)
+			if !solver.board.Is(pos, EmptyCellRevealed) {
+				continue
+			}
+
+			c := constraint{center: pos, bombs: solver.board.CountNeighborBombs(solver.topology, pos)}
+			for _, neighbor := range solver.board.Neighbors(solver.topology, pos) {
+				if solver.isKnownBomb(neighbor) {
+					c.bombs--
+					continue
+				}
+
+				if solver.board.IsCovered(neighbor) {
+					c.cells = append(c.cells, neighbor)
+				}
+			}
+
+			if len(c.cells) > 0 {
+				constraints = append(constraints, c)
+			}
+		}
+	}
+
+	return constraints
+}
+
+// unknown returns the covered cells that are not known to have a bomb
+func (solver *Solver) unknown() []Position {
+	var cells []Position
+	var pos Position
+
+	for row := range solver.board {
+		for column := range solver.board[0] {
+			pos = NewPosition(row, column)
+			if solver.board.IsCovered(pos) && !solver.isKnownBomb(pos) {
+				cells = append(cells, pos)
+			}
+		}
+	}
+
+	return cells
+}
+
+func (solver *Solver) isExpired() bool {
+	return solver.expired != nil && solver.expired()
+}
+
+func (solver *Solver) isKnownBomb(pos Position) bool {
+	return solver.bombs[pos] || solver.board.Is(pos, BombCellRevealed)
+}
+
+// CountUnsolvable returns how many empty cells cannot be revealed without guessing when the game starts at the given position.
+// The solver stops as soon as expired returns true, if given, counting the cells it has not revealed yet as unsolvable.
+// The board is not modified
+func (board Board) CountUnsolvable(topology Topology, start Position, bombsNumber int, expired func() bool) int {
+	sim := board.Copy()
+	for row := range sim {
+		for column := range sim[0] {
+			if sim.Is(NewPosition(row, column), EmptyCellCoveredAndMarked, EmptyCellCoveredAndQuestioned) {
+				sim.Set(NewPosition(row, column), EmptyCellCovered)
+			}
+		}
+	}
+
+	if !sim.HasBomb(start) {
+		sim.RevealInCascade(topology, start)
+
+		solver := NewSolver(sim, topology, bombsNumber)
+		solver.StopWhen(expired)
+		for progress := true; progress && !solver.isExpired(); {
+			progress = false
+
+			for _, deduction := range solver.Deduce() {
+				if deduction.Bomb {
+					solver.AddBomb(deduction.Position)
+					progress = true
+					continue
+				}
+
+				if sim.Is(deduction.Position, EmptyCellCovered) {
+					sim.RevealInCascade(topology, deduction.Position)
+					progress = true
+				}
+			}
+		}
+	}
+
+	return sim.Count(EmptyCellCovered)
+}
+
+// IsSolvable returns true if all the empty cells can be revealed without guessing when the game starts at the given position
+func (board Board) IsSolvable(topology Topology, start Position, bombsNumber int) bool {
+	return board.CountUnsolvable(topology, start, bombsNumber, nil) == 0
+}
+
+func isSubset(a []Position, b []Position) bool {
+	for _, x := range a {
+		if !contains(b, x) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func difference(a []Position, b []Position) []Position {
+	var result []Position
+	for _, x := range a {
+		if !contains(b, x) {
+			result = append(result, x)
+		}
+	}
+
+	return result
+}
+
+func contains(positions []Position, pos Position) bool {
+	for _, p := range positions {
+		if p == pos {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSolver_Deduce(t *testing.T) {
+	type args struct {
+		board       domain.Board
+		bombsNumber int
+		knownBombs  []domain.Position
+	}
+
+	type want struct {
+		safe  []domain.Position
+		bombs []domain.Position
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "deduce safe cells around a satisfied cell",
+			args: args{board: domain.Board{
+				{e, b, e},
+				{E, E, E},
+			}, bombsNumber: 1, knownBombs: []domain.Position{{Row: 0, Column: 1}}},
+			want: want{safe: []domain.Position{{Row: 0, Column: 0}, {Row: 0, Column: 2}}},
+		},
+		{
+			name: "deduce bombs around a cell with as many covered neighbors as bombs",
+			args: args{board: domain.Board{
+				{b, E, E},
+				{E, E, e},
+			}, bombsNumber: 1},
+			want: want{safe: []domain.Position{{Row: 1, Column: 2}}, bombs: []domain.Position{{Row: 0, Column: 0}}},
+		},
+		{
+			name: "deduce cells from a pair of overlapping cells",
+			args: args{board: domain.Board{
+				{b, e, b, e},
+				{E, E, E, E},
+				{E, E, E, E},
+			}, bombsNumber: 2},
+			want: want{safe: []domain.Position{{Row: 0, Column: 1}}, bombs: []domain.Position{{Row: 0, Column: 2}}},
+		},
+		{
+			name: "deduce cells from the total number of bombs",
+			args: args{board: domain.Board{
+				{E, E, E},
+				{E, E, E},
+				{E, E, E},
+				{e, E, e},
+			}, bombsNumber: 0},
+			want: want{safe: []domain.Position{{Row: 3, Column: 0}, {Row: 3, Column: 2}}},
+		},
+		{
+			name: "nothing to deduce without guessing",
+			args: args{board: domain.Board{
+				{e, b},
+				{E, e},
+			}, bombsNumber: 1},
+			want: want{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			solver := domain.NewSolver(tt.args.board, domain.StandardTopology, tt.args.bombsNumber)
+			for _, pos := range tt.args.knownBombs {
+				solver.AddBomb(pos)
+			}
+
+			var safe, bombs []domain.Position
+			for _, deduction := range solver.Deduce() {
+				assert.NotEmpty(t, deduction.Reason)
+
+				if deduction.Bomb {
+					bombs = append(bombs, deduction.Position)
+					continue
+				}
+
+				safe = append(safe, deduction.Position)
+			}
+
+			assert.ElementsMatch(t, tt.want.safe, safe)
+			assert.ElementsMatch(t, tt.want.bombs, bombs)
+		})
+	}
+}
+
+func TestBoard_CountUnsolvable(t *testing.T) {
+	type args struct {
+		board       domain.Board
+		start       domain.Position
+		bombsNumber int
+		expired     func() bool
+	}
+
+	type want struct {
+		result   int
+		solvable bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "solvable board",
+			args: args{board: domain.Board{
+				{e, e, e},
+				{e, e, e},
+				{e, e, b},
+			}, start: domain.NewPosition(0, 0), bombsNumber: 1},
+			want: want{result: 0, solvable: true},
+		},
+		{
+			name: "solvable board using the total number of bombs",
+			args: args{board: domain.Board{
+				{e, b, e},
+			}, start: domain.NewPosition(0, 0), bombsNumber: 1},
+			want: want{result: 0, solvable: true},
+		},
+		{
+			name: "board that needs a guess",
+			args: args{board: domain.Board{
+				{e, e, e},
+				{e, b, e},
+				{e, e, e},
+			}, start: domain.NewPosition(0, 0), bombsNumber: 1},
+			want: want{result: 7, solvable: false},
+		},
+		{
+			name: "board ending in a 50/50",
+			args: args{board: domain.Board{
+				{e, e, b},
+				{e, e, e},
+			}, start: domain.NewPosition(0, 0), bombsNumber: 1},
+			want: want{result: 1, solvable: false},
+		},
+		{
+			name: "solver stops when expired",
+			args: args{board: domain.Board{
+				{e, b, e},
+			}, start: domain.NewPosition(0, 0), bombsNumber: 1, expired: func() bool { return true }},
+			want: want{result: 1, solvable: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.args.board.Copy()
+
+			assert.Equal(t, tt.want.result, tt.args.board.CountUnsolvable(domain.StandardTopology, tt.args.start, tt.args.bombsNumber, tt.args.expired))
+			assert.Equal(t, tt.want.solvable, tt.args.board.IsSolvable(domain.StandardTopology, tt.args.start, tt.args.bombsNumber))
+			assert.Equal(t, original, tt.args.board)
+		})
+	}
+}
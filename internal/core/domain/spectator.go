@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// ShareLink lets anyone that knows its token watch a game without being able to play it. UserID is the participant of
+// the game that created the link
+type ShareLink struct {
+	Token     string    `json:"token"`
+	GameID    string    `json:"game_id"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SpectatorView is a game as seen by its spectators. It leaves out the users that play the game
+type SpectatorView struct {
+	Board          Board        `json:"board"`
+	Settings       GameSettings `json:"settings"`
+	State          string       `json:"state"`
+	RemainingLives int          `json:"remaining_lives"`
+	Moves          int          `json:"moves"`
+	Progress       Progress     `json:"progress"`
+	StartedAt      time.Time    `json:"started_at"`
+	EndedAt        time.Time    `json:"ended_at"`
+}
+
+// NewSpectatorView returns the game as seen by its spectators at the given time. The bombs are hidden as they are for
+// the player until the game ends, when they are shown
+func NewSpectatorView(game Game, now time.Time) SpectatorView {
+	board := game.Board.Copy()
+	if !game.IsFinished() {
+		board.HideBombs()
+	}
+
+	return SpectatorView{
+		Board:          board,
+		Settings:       game.Settings,
+		State:          game.State,
+		RemainingLives: game.RemainingLives,
+		Moves:          game.Moves,
+		Progress:       game.Progress(now),
+		StartedAt:      game.StartedAt,
+		EndedAt:        game.EndedAt,
+	}
+}
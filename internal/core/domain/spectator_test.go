@@ -0,0 +1,74 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewSpectatorView(t *testing.T) {
+	startedAt := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	newGame := func(state string) domain.Game {
+		return domain.Game{
+			ID:           "xyz",
+			UserID:       "111",
+			Participants: []string{"222"},
+			Log:          []domain.ActionRecord{{UserID: "222", Action: domain.Action{Type: domain.ActionReveal}, Move: 1}},
+			Board:        domain.Board{{E, b}, {Y, e}},
+			Settings:     domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 2},
+			State:        state,
+			Moves:        2,
+			StartedAt:    startedAt,
+		}
+	}
+
+	tests := []struct {
+		name string
+		game domain.Game
+		want domain.SpectatorView
+	}{
+		{
+			name: "bombs are hidden while the game goes on",
+			game: newGame(domain.GameStateOnGoing),
+			want: domain.SpectatorView{
+				Board:     domain.Board{{E, e}, {X, e}},
+				Settings:  domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 2},
+				State:     domain.GameStateOnGoing,
+				Moves:     2,
+				Progress:  domain.Progress{MinesRemaining: 1, CoveredCells: 3, RevealedPercentage: 50, ElapsedSeconds: 30},
+				StartedAt: startedAt,
+			},
+		},
+		{
+			name: "bombs are shown once the game ends",
+			game: func() domain.Game {
+				game := newGame(domain.GameStateLost)
+				game.EndedAt = startedAt.Add(10 * time.Second)
+				return game
+			}(),
+			want: domain.SpectatorView{
+				Board:     domain.Board{{E, b}, {Y, e}},
+				Settings:  domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 2},
+				State:     domain.GameStateLost,
+				Moves:     2,
+				Progress:  domain.Progress{MinesRemaining: 1, CoveredCells: 3, RevealedPercentage: 50, ElapsedSeconds: 10},
+				StartedAt: startedAt,
+				EndedAt:   startedAt.Add(10 * time.Second),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			board := tt.game.Board.Copy()
+			view := domain.NewSpectatorView(tt.game, startedAt.Add(30*time.Second))
+
+			assert.Equal(t, tt.want, view)
+			assert.Equal(t, board, tt.game.Board)
+		})
+	}
+}
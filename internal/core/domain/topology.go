@@ -0,0 +1,151 @@
+package domain
+
+const (
+	TopologyStandard = "standard"
+	TopologyTorus    = "torus"
+	TopologyHex      = "hex"
+)
+
+const (
+	CoordinatesOffset = "offset"
+	CoordinatesAxial  = "axial"
+)
+
+// Topology defines which cells of a board are adjacent. Every rule that looks at the neighbors of a cell, as the
+// numbers, the cascade or the solver, goes through the topology of the game
+type Topology interface {
+	// AppendNeighbors appends to neighbors the valid positions around the given one, without repetitions, and returns
+	// the extended slice. Passing a reused slice avoids allocations when it is called for every cell of the board
+	AppendNeighbors(neighbors []Position, board Board, pos Position) []Position
+}
+
+var (
+	// StandardTopology is the classic one: every cell touches the 8 cells around it that are within the board
+	StandardTopology Topology = standardTopology{}
+	// TorusTopology wraps the edges around, so the first and last rows and columns are adjacent and every cell has 8 neighbors
+	TorusTopology Topology = torusTopology{}
+	// HexTopology lays the cells out as hexagons, every one touching 6 cells. Positions are offset coordinates where
+	// the odd rows are shifted half a cell to the right, so a hexagonal board is stored as any rectangular board
+	HexTopology Topology = hexTopology{}
+)
+
+var topologies = map[string]Topology{
+	"":               StandardTopology,
+	TopologyStandard: StandardTopology,
+	TopologyTorus:    TorusTopology,
+	TopologyHex:      HexTopology,
+}
+
+// NewTopology returns the topology with the given name, where an empty name means the standard one.
+// Returns false if the name is unknown
+func NewTopology(name string) (Topology, bool) {
+	topology, ok := topologies[name]
+	return topology, ok
+}
+
+type standardTopology struct{}
+
+func (standardTopology) AppendNeighbors(neighbors []Position, board Board, pos Position) []Position {
+	var current Position
+
+	for di := -1; di <= 1; di++ {
+		for dj := -1; dj <= 1; dj++ {
+			current = NewPosition(pos.Row+di, pos.Column+dj)
+
+			if current == pos || !board.IsValidPosition(current) {
+				continue
+			}
+
+			neighbors = append(neighbors, current)
+		}
+	}
+
+	return neighbors
+}
+
+type torusTopology struct{}
+
+func (torusTopology) AppendNeighbors(neighbors []Position, board Board, pos Position) []Position {
+	rows, columns := len(board), len(board[0])
+	start := len(neighbors)
+	var current Position
+
+	for di := -1; di <= 1; di++ {
+		for dj := -1; dj <= 1; dj++ {
+			current = NewPosition((pos.Row+di+rows)%rows, (pos.Column+dj+columns)%columns)
+
+			// On boards with less than 3 rows or columns, different offsets wrap to the same cell
+			if current == pos || contains(neighbors[start:], current) {
+				continue
+			}
+
+			neighbors = append(neighbors, current)
+		}
+	}
+
+	return neighbors
+}
+
+type hexTopology struct{}
+
+// hexOffsets are the relative positions of the neighbors of a cell in an even and in an odd row
+var hexOffsets = [2][6]Position{
+	{{Row: -1, Column: -1}, {Row: -1, Column: 0}, {Row: 0, Column: -1}, {Row: 0, Column: 1}, {Row: 1, Column: -1}, {Row: 1, Column: 0}},
+	{{Row: -1, Column: 0}, {Row: -1, Column: 1}, {Row: 0, Column: -1}, {Row: 0, Column: 1}, {Row: 1, Column: 0}, {Row: 1, Column: 1}},
+}
+
+func (hexTopology) AppendNeighbors(neighbors []Position, board Board, pos Position) []Position {
+	var current Position
+
+	for _, offset := range hexOffsets[pos.Row&1] {
+		current = NewPosition(pos.Row+offset.Row, pos.Column+offset.Column)
+
+		if board.IsValidPosition(current) {
+			neighbors = append(neighbors, current)
+		}
+	}
+
+	return neighbors
+}
+
+// Coordinates locate a cell of a board: offset coordinates give its row and column and can be used in any board, while
+// axial coordinates give its q and r and can only be used in hexagonal boards. Only the row and column are encoded, as
+// the actions are always recorded and reported with offset coordinates
+type Coordinates struct {
+	System string `json:"-"`
+	Row    int    `json:"row"`
+	Column int    `json:"column"`
+	Q      int    `json:"-"`
+	R      int    `json:"-"`
+}
+
+func NewOffsetCoordinates(row int, column int) Coordinates {
+	return Coordinates{System: CoordinatesOffset, Row: row, Column: column}
+}
+
+func NewAxialCoordinates(q int, r int) Coordinates {
+	return Coordinates{System: CoordinatesAxial, Q: q, R: r}
+}
+
+// Position returns the position of the cell located by the coordinates in a board with the given topology. Returns
+// false if the coordinate system cannot be used in that topology
+func (coordinates Coordinates) Position(topology Topology) (Position, bool) {
+	switch coordinates.System {
+	case CoordinatesOffset:
+		return NewPosition(coordinates.Row, coordinates.Column), true
+	case CoordinatesAxial:
+		return NewPositionFromAxial(coordinates.Q, coordinates.R), topology == HexTopology
+	default:
+		return Position{}, false
+	}
+}
+
+// NewPositionFromAxial returns the position of the cell of a hexagonal board with the given axial coordinates
+func NewPositionFromAxial(q int, r int) Position {
+	return NewPosition(r, q+(r-(r&1))/2)
+}
+
+// Axial returns the axial coordinates q and r of the position in a hexagonal board
+func (pos Position) Axial() (int, int) {
+	return pos.Column - (pos.Row-(pos.Row&1))/2, pos.Row
+}
@@ -0,0 +1,203 @@
+package domain_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTopology(t *testing.T) {
+	topology, ok := domain.NewTopology("")
+	assert.True(t, ok)
+	assert.Equal(t, domain.StandardTopology, topology)
+
+	topology, ok = domain.NewTopology(domain.TopologyStandard)
+	assert.True(t, ok)
+	assert.Equal(t, domain.StandardTopology, topology)
+
+	topology, ok = domain.NewTopology(domain.TopologyTorus)
+	assert.True(t, ok)
+	assert.Equal(t, domain.TorusTopology, topology)
+
+	topology, ok = domain.NewTopology(domain.TopologyHex)
+	assert.True(t, ok)
+	assert.Equal(t, domain.HexTopology, topology)
+
+	_, ok = domain.NewTopology("sphere")
+	assert.False(t, ok)
+}
+
+func TestTopology_AppendNeighbors(t *testing.T) {
+	type args struct {
+		board domain.Board
+		pos   domain.Position
+	}
+
+	tests := []struct {
+		name     string
+		topology domain.Topology
+		args     args
+		want     []domain.Position
+	}{
+		{
+			name:     "standard corner",
+			topology: domain.StandardTopology,
+			args:     args{board: domain.NewEmptyBoard(3, 4), pos: domain.NewPosition(0, 0)},
+			want:     []domain.Position{{Row: 0, Column: 1}, {Row: 1, Column: 0}, {Row: 1, Column: 1}},
+		},
+		{
+			name:     "torus corner",
+			topology: domain.TorusTopology,
+			args:     args{board: domain.NewEmptyBoard(3, 4), pos: domain.NewPosition(0, 0)},
+			want: []domain.Position{
+				{Row: 2, Column: 3}, {Row: 2, Column: 0}, {Row: 2, Column: 1},
+				{Row: 0, Column: 3}, {Row: 0, Column: 1},
+				{Row: 1, Column: 3}, {Row: 1, Column: 0}, {Row: 1, Column: 1},
+			},
+		},
+		{
+			name:     "torus with two columns",
+			topology: domain.TorusTopology,
+			args:     args{board: domain.NewEmptyBoard(3, 2), pos: domain.NewPosition(1, 0)},
+			want: []domain.Position{
+				{Row: 0, Column: 1}, {Row: 0, Column: 0},
+				{Row: 1, Column: 1},
+				{Row: 2, Column: 1}, {Row: 2, Column: 0},
+			},
+		},
+		{
+			name:     "hex even row",
+			topology: domain.HexTopology,
+			args:     args{board: domain.NewEmptyBoard(4, 4), pos: domain.NewPosition(2, 2)},
+			want: []domain.Position{
+				{Row: 1, Column: 1}, {Row: 1, Column: 2},
+				{Row: 2, Column: 1}, {Row: 2, Column: 3},
+				{Row: 3, Column: 1}, {Row: 3, Column: 2},
+			},
+		},
+		{
+			name:     "hex odd row",
+			topology: domain.HexTopology,
+			args:     args{board: domain.NewEmptyBoard(4, 4), pos: domain.NewPosition(1, 2)},
+			want: []domain.Position{
+				{Row: 0, Column: 2}, {Row: 0, Column: 3},
+				{Row: 1, Column: 1}, {Row: 1, Column: 3},
+				{Row: 2, Column: 2}, {Row: 2, Column: 3},
+			},
+		},
+		{
+			name:     "hex corner",
+			topology: domain.HexTopology,
+			args:     args{board: domain.NewEmptyBoard(4, 4), pos: domain.NewPosition(0, 0)},
+			want:     []domain.Position{{Row: 0, Column: 1}, {Row: 1, Column: 0}},
+		},
+		{
+			name:     "torus with a single cell",
+			topology: domain.TorusTopology,
+			args:     args{board: domain.NewEmptyBoard(1, 1), pos: domain.NewPosition(0, 0)},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.topology.AppendNeighbors(nil, tt.args.board, tt.args.pos)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBoard_RevealInCascade_Torus(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, e, e, e, e},
+		{e, e, e, e, e},
+		{e, e, e, b, e},
+		{e, e, e, e, e},
+	}
+
+	// Execute
+	revealed := board.RevealInCascade(domain.TorusTopology, domain.NewPosition(0, 0))
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{E, E, E, E, E},
+		{E, E, E, E, E},
+		{E, E, E, b, E},
+		{E, E, E, E, E},
+	}, board)
+	assert.Len(t, revealed, 19)
+	assert.Equal(t, 1, board.CountNeighborBombs(domain.TorusTopology, domain.NewPosition(3, 4)))
+	assert.Equal(t, 0, board.CountNeighborBombs(domain.TorusTopology, domain.NewPosition(0, 0)))
+}
+
+func TestBoard_Metrics_Torus(t *testing.T) {
+	board := domain.Board{
+		{e, e, e, e},
+		{e, b, e, e},
+		{e, e, e, e},
+		{e, e, e, e},
+	}
+
+	assert.Equal(t, domain.BoardMetrics{ThreeBV: 1, Openings: 1, Islands: 0}, board.Metrics(domain.TorusTopology))
+	assert.Equal(t, domain.BoardMetrics{ThreeBV: 4, Openings: 1, Islands: 1}, board.Metrics(domain.StandardTopology))
+}
+
+func TestNewPositionFromAxial(t *testing.T) {
+	assert.Equal(t, domain.NewPosition(0, 0), domain.NewPositionFromAxial(0, 0))
+	assert.Equal(t, domain.NewPosition(1, 2), domain.NewPositionFromAxial(2, 1))
+	assert.Equal(t, domain.NewPosition(2, 2), domain.NewPositionFromAxial(1, 2))
+	assert.Equal(t, domain.NewPosition(3, 0), domain.NewPositionFromAxial(-1, 3))
+}
+
+func TestPosition_Axial(t *testing.T) {
+	for row := -3; row <= 3; row++ {
+		for column := -3; column <= 3; column++ {
+			q, r := domain.NewPosition(row, column).Axial()
+
+			assert.Equal(t, domain.NewPosition(row, column), domain.NewPositionFromAxial(q, r))
+		}
+	}
+}
+
+func TestCoordinates_Position(t *testing.T) {
+	pos, ok := domain.NewOffsetCoordinates(1, 2).Position(domain.TorusTopology)
+	assert.True(t, ok)
+	assert.Equal(t, domain.NewPosition(1, 2), pos)
+
+	pos, ok = domain.NewAxialCoordinates(2, 1).Position(domain.HexTopology)
+	assert.True(t, ok)
+	assert.Equal(t, domain.NewPosition(1, 2), pos)
+
+	_, ok = domain.NewAxialCoordinates(2, 1).Position(domain.StandardTopology)
+	assert.False(t, ok)
+
+	_, ok = domain.Coordinates{}.Position(domain.HexTopology)
+	assert.False(t, ok)
+}
+
+func TestBoard_RevealInCascade_Hex(t *testing.T) {
+	// Setup
+	board := domain.Board{
+		{e, e, e, e},
+		{e, b, e, e},
+		{e, e, e, e},
+	}
+
+	// Execute
+	revealed := board.RevealInCascade(domain.HexTopology, domain.NewPosition(2, 3))
+
+	// Verify
+	assert.Equal(t, domain.Board{
+		{e, e, E, E},
+		{e, b, E, E},
+		{e, e, E, E},
+	}, board)
+	assert.Len(t, revealed, 6)
+	assert.Equal(t, 0, board.CountNeighborBombs(domain.HexTopology, domain.NewPosition(0, 0)))
+	assert.Equal(t, 1, board.CountNeighborBombs(domain.HexTopology, domain.NewPosition(1, 0)))
+	assert.Equal(t, 1, board.CountNeighborBombs(domain.HexTopology, domain.NewPosition(2, 2)))
+}
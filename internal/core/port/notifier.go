@@ -0,0 +1,21 @@
+package port
+
+import "github.com/matiasvarela/minesweeper-API/internal/core/domain"
+
+//go:generate mockgen -source=notifier.go -destination=../../../mock/notifier.go -package=mock
+
+type MatchNotifier interface {
+	Publish(match domain.Match)
+	// Subscribe returns the channel where the new states of the match are received and the function that stops
+	// receiving them
+	Subscribe(matchID string) (<-chan domain.Match, func())
+}
+
+type GameNotifier interface {
+	Publish(game domain.Game)
+	// Subscribe returns the channel where the new states of the game are received and the function that stops
+	// receiving them. The subscription is also stopped when the given key is cancelled
+	Subscribe(gameID string, key string) (<-chan domain.Game, func())
+	// Cancel stops every subscription made with the given key, closing their channels
+	Cancel(key string)
+}
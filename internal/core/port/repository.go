@@ -7,5 +7,27 @@ import "github.com/matiasvarela/minesweeper-API/internal/core/domain"
 type GameRepository interface {
 	Get(userID string, gameID string) (*domain.Game, error)
 	GetAll(userID string) ([]domain.Game, error)
+	GetAllTimed() ([]domain.Game, error)
+	GetAllByPuzzle(puzzleID string) ([]domain.Game, error)
+	// Save stores the game. Versioned games are stored only if they have not been saved since they were read, that is
+	// if the stored one has the previous version. Otherwise it fails with a conflict error
 	Save(game domain.Game) error
 }
+
+type PuzzleRepository interface {
+	Get(puzzleID string) (*domain.Puzzle, error)
+	Save(puzzle domain.Puzzle) error
+}
+
+type MatchRepository interface {
+	Get(matchID string) (*domain.Match, error)
+	// Save stores the match only if it has not been saved since it was read, that is if the stored one has the
+	// previous version. Otherwise it fails with a conflict error
+	Save(match domain.Match) error
+}
+
+type ShareLinkRepository interface {
+	Get(token string) (*domain.ShareLink, error)
+	Save(link domain.ShareLink) error
+	Delete(token string) error
+}
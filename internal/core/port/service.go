@@ -2,10 +2,40 @@ package port
 
 import "github.com/matiasvarela/minesweeper-API/internal/core/domain"
 
+//go:generate mockgen -source=service.go -destination=../../../mock/service.go -package=mock
+
 type GameService interface {
 	Get(userID string, gameID string) (domain.Game, error)
 	GetAll(userID string) ([]domain.Game, error)
 	Create(userID string, settings domain.GameSettings) (domain.Game, error)
-	MarkCell(userID string, gameID string, row int, column int) (domain.Game, error)
-	RevealCell(userID string, gameID string, row int, column int) (domain.Game, error)
+	MarkCell(userID string, gameID string, coordinates domain.Coordinates) (domain.Game, []domain.Position, error)
+	RevealCell(userID string, gameID string, coordinates domain.Coordinates) (domain.Game, []domain.Position, error)
+	Hint(userID string, gameID string) (domain.Game, domain.Hint, error)
+	Analyze(userID string, gameID string) (domain.Analysis, error)
+	Undo(userID string, gameID string) (domain.Game, error)
+	Retry(userID string, gameID string) (domain.Game, error)
+	Act(userID string, gameID string, actions []domain.Action) (domain.Game, []domain.ActionResult, error)
+	AddParticipant(userID string, gameID string, participantID string) (domain.Game, error)
+	RemoveParticipant(userID string, gameID string, participantID string) (domain.Game, error)
+	CreateMatchGames(matchID string, userIDs []string, settings domain.GameSettings) ([]domain.Game, error)
+	ExpireGames() error
+}
+
+type PuzzleService interface {
+	Get(puzzleID string) (domain.Puzzle, error)
+	Create(design domain.PuzzleDesign) (domain.Puzzle, error)
+	Leaderboard(puzzleID string) ([]domain.LeaderboardEntry, error)
+}
+
+type MatchService interface {
+	Get(userID string, matchID string) (domain.Match, error)
+	Create(userID string, request domain.MatchRequest) (domain.Match, error)
+	Subscribe(userID string, matchID string) (domain.Match, <-chan domain.Match, func(), error)
+}
+
+type SpectatorService interface {
+	Share(userID string, gameID string) (domain.ShareLink, error)
+	Revoke(userID string, gameID string, token string) error
+	Get(token string) (domain.SpectatorView, error)
+	Subscribe(token string) (domain.SpectatorView, <-chan domain.SpectatorView, func(), error)
 }
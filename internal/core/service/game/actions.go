@@ -0,0 +1,207 @@
+package game
+
+import (
+	"fmt"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+)
+
+const (
+	maxActions = 1000
+)
+
+// Act applies the given actions in order to the game, loading and saving it only once. Actions are validated before
+// applying any of them, so either all of them are considered or none. Once the game ends the remaining actions are skipped.
+// Their cells are located as in the single cell actions, and the results and the log give them by row and column
+func (srv *service) Act(userID string, gameID string, actions []domain.Action) (domain.Game, []domain.ActionResult, error) {
+	if len(actions) == 0 || len(actions) > maxActions {
+		return domain.Game{}, nil, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the number of actions must be between 1 and %d", maxActions), "")
+	}
+
+	var results []domain.ActionResult
+
+	game, err := srv.update(userID, gameID, func(game *domain.Game) (bool, error) {
+		if game.IsFinished() {
+			return false, errors.New(apperrors.InvalidInput, nil, "game has already finished", "")
+		}
+
+		positions := make([]domain.Position, len(actions))
+		for i, action := range actions {
+			switch action.Type {
+			case domain.ActionReveal, domain.ActionMark, domain.ActionChord:
+			default:
+				return false, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("invalid type of action %d", i), "")
+			}
+
+			pos, err := locate(*game, action.Coordinates)
+			if err != nil {
+				return false, errors.New(apperrors.InvalidInput, err, fmt.Sprintf("%s of action %d", err.Error(), i), "")
+			}
+
+			positions[i] = pos
+		}
+
+		results = make([]domain.ActionResult, len(actions))
+		changed := false
+
+		for i, pos := range positions {
+			action := domain.Action{Type: actions[i].Type, Coordinates: domain.NewOffsetCoordinates(pos.Row, pos.Column)}
+			results[i] = domain.ActionResult{Action: action, Status: domain.ActionStatusSkipped}
+			if game.IsFinished() {
+				continue
+			}
+
+			var cells []domain.Position
+
+			switch action.Type {
+			case domain.ActionReveal:
+				cells = srv.reveal(game, pos)
+			case domain.ActionMark:
+				cells = srv.mark(game, pos)
+			case domain.ActionChord:
+				cells = srv.chord(game, pos)
+			}
+
+			results[i].Status = domain.ActionStatusIgnored
+			if len(cells) > 0 {
+				results[i].Status = domain.ActionStatusApplied
+				record(game, userID, action)
+				changed = true
+			}
+		}
+
+		return changed, nil
+	})
+	if err != nil {
+		return domain.Game{}, nil, errors.Wrap(err, err.Error())
+	}
+
+	return game, results, nil
+}
+
+// mark moves the given cell to its next mark and returns it; returns empty if the cell cannot be marked
+func (srv *service) mark(game *domain.Game, pos domain.Position) []domain.Position {
+	if !game.Board.IsCovered(pos) {
+		return nil
+	}
+
+	remember(game)
+
+	switch game.Board.Get(pos) {
+	case domain.EmptyCellCovered:
+		game.Board.Set(pos, domain.EmptyCellCoveredAndMarked)
+	case domain.EmptyCellCoveredAndMarked:
+		if game.Settings.QuestionMarks {
+			game.Board.Set(pos, domain.EmptyCellCoveredAndQuestioned)
+		} else {
+			game.Board.Set(pos, domain.EmptyCellCovered)
+		}
+	case domain.EmptyCellCoveredAndQuestioned:
+		game.Board.Set(pos, domain.EmptyCellCovered)
+	case domain.BombCellCovered:
+		game.Board.Set(pos, domain.BombCellCoveredAndMarked)
+	case domain.BombCellCoveredAndMarked:
+		if game.Settings.QuestionMarks {
+			game.Board.Set(pos, domain.BombCellCoveredAndQuestioned)
+		} else {
+			game.Board.Set(pos, domain.BombCellCovered)
+		}
+	case domain.BombCellCoveredAndQuestioned:
+		game.Board.Set(pos, domain.BombCellCovered)
+	}
+
+	game.Moves++
+
+	return []domain.Position{pos}
+}
+
+// reveal reveals the given cell, starting the game if it is the first one, and returns the cells revealed;
+// returns empty if the cell cannot be revealed
+func (srv *service) reveal(game *domain.Game, pos domain.Position) []domain.Position {
+	if !game.Board.IsRevealable(pos) {
+		return nil
+	}
+
+	remember(game)
+
+	if game.State == domain.GameStateNew {
+		srv.startGame(game, pos)
+	}
+
+	game.Moves++
+
+	return srv.open(game, pos)
+}
+
+// chord reveals at once the covered neighbors of a revealed cell that already has as many flags around as bombs and
+// returns the cells revealed. Returns empty if the flags around do not match the number of the cell or there is nothing
+// left to reveal
+func (srv *service) chord(game *domain.Game, pos domain.Position) []domain.Position {
+	if game.State != domain.GameStateOnGoing || !game.Board.Is(pos, domain.EmptyCellRevealed) {
+		return nil
+	}
+
+	var flags int
+	var targets []domain.Position
+
+	for _, neighbor := range game.Board.Neighbors(game.Topology(), pos) {
+		switch {
+		case game.Board.Is(neighbor, domain.EmptyCellCoveredAndMarked, domain.BombCellCoveredAndMarked):
+			flags++
+		case game.Board.IsRevealable(neighbor):
+			targets = append(targets, neighbor)
+		}
+	}
+
+	if len(targets) == 0 || flags != game.Board.CountNeighborBombs(game.Topology(), pos) {
+		return nil
+	}
+
+	remember(game)
+	game.Moves++
+
+	var revealed []domain.Position
+	for _, target := range targets {
+		if game.IsFinished() {
+			break
+		}
+
+		if game.Board.IsRevealable(target) {
+			revealed = append(revealed, srv.open(game, target)...)
+		}
+	}
+
+	return revealed
+}
+
+// open reveals a covered cell of a started game and returns the cells revealed. Revealing a bomb consumes a life and
+// the game is lost when no lives remain; revealing the last empty cell wins the game
+func (srv *service) open(game *domain.Game, pos domain.Position) []domain.Position {
+	var revealed []domain.Position
+
+	switch game.Board.Get(pos) {
+	case domain.EmptyCellCovered, domain.EmptyCellCoveredAndQuestioned:
+		revealed = game.Board.RevealInCascade(game.Topology(), pos)
+
+		if game.Board.Count(domain.EmptyCellRevealed) == game.Board.CountEnabled()-game.Settings.BombsNumber {
+			game.State = domain.GameStateWon
+			game.EndedAt = srv.clock.Now()
+			game.Performance = game.Rate()
+		}
+	case domain.BombCellCovered, domain.BombCellCoveredAndQuestioned:
+		game.Board.Set(pos, domain.BombCellRevealed)
+		revealed = []domain.Position{pos}
+
+		if game.RemainingLives > 0 {
+			game.RemainingLives--
+		}
+
+		if game.RemainingLives == 0 {
+			game.State = domain.GameStateLost
+			game.EndedAt = srv.clock.Now()
+		}
+	}
+
+	return revealed
+}
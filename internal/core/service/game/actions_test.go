@@ -0,0 +1,206 @@
+package game_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/service/game"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestService_Act(t *testing.T) {
+	type args struct {
+		userID  string
+		gameID  string
+		actions []domain.Action
+	}
+	type want struct {
+		result  domain.Game
+		results []domain.ActionResult
+		err     error
+	}
+
+	reveal := domain.Action{Type: domain.ActionReveal, Coordinates: domain.NewOffsetCoordinates(0, 1)}
+	mark := domain.Action{Type: domain.ActionMark, Coordinates: domain.NewOffsetCoordinates(0, 2)}
+	chord := domain.Action{Type: domain.ActionChord, Coordinates: domain.NewOffsetCoordinates(0, 1)}
+	late := domain.Action{Type: domain.ActionReveal, Coordinates: domain.NewOffsetCoordinates(1, 0)}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "apply actions until the game is won",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{reveal, mark, chord, late}},
+			want: want{
+				result: MockGameWithBoard("111", "xyz", domain.GameStateWon, 1, domain.Board{
+					{E, E, Y},
+					{E, E, E},
+				}, time.Time{}, time.Time{}, withMoves(3), withVersion(1)),
+				results: []domain.ActionResult{
+					{Action: reveal, Status: domain.ActionStatusApplied},
+					{Action: mark, Status: domain.ActionStatusApplied},
+					{Action: chord, Status: domain.ActionStatusApplied},
+					{Action: late, Status: domain.ActionStatusSkipped},
+				},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+					{e, e, b},
+					{e, e, e},
+				}, time.Time{}, time.Time{})
+				dep.clock.EXPECT().Now().Return(time.Time{})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "chord with a wrong flag and lost game",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{chord}},
+			want: want{
+				result: MockGameWithBoard("111", "xyz", domain.GameStateLost, 1, domain.Board{
+					{X, E, B},
+					{e, e, e},
+				}, time.Time{}, time.Time{}, withMoves(1), withVersion(1)),
+				results: []domain.ActionResult{
+					{Action: chord, Status: domain.ActionStatusApplied},
+				},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withLives(0, 1))
+				game.Board = domain.Board{
+					{X, E, b},
+					{e, e, e},
+				}
+				game.Settings = domain.GameSettings{Rows: 2, Columns: 3, BombsNumber: 1}
+				dep.clock.EXPECT().Now().Return(time.Time{})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "actions that do not change the game are ignored",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{chord}},
+			want: want{
+				result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+					{e, E, b},
+					{e, e, e},
+				}, time.Time{}, time.Time{}),
+				results: []domain.ActionResult{
+					{Action: chord, Status: domain.ActionStatusIgnored},
+				},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+					{e, E, b},
+					{e, e, e},
+				}, time.Time{}, time.Time{})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "no actions",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "the number of actions must be between 1 and 1000", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid type of action",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{reveal, {Type: "jump"}}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "invalid type of action 1", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "invalid position of action",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{{Type: domain.ActionMark, Coordinates: domain.NewOffsetCoordinates(-1, 0)}}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "invalid row and column parameters of action 0", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "apply action given by axial coordinates in a hexagonal game",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{{Type: domain.ActionMark, Coordinates: domain.NewAxialCoordinates(2, 0)}}},
+			want: want{
+				result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+					{e, e, Y},
+					{e, e, e},
+				}, time.Time{}, time.Time{}, withTopology(domain.TopologyHex), withMoves(1), withVersion(1)),
+				results: []domain.ActionResult{
+					{Action: mark, Status: domain.ActionStatusApplied},
+				},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+					{e, e, b},
+					{e, e, e},
+				}, time.Time{}, time.Time{}, withTopology(domain.TopologyHex))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "axial coordinates of action in a game that is not hexagonal",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{reveal, {Type: domain.ActionMark, Coordinates: domain.NewAxialCoordinates(2, 0)}}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "axial coordinates can only be used in hexagonal boards of action 1", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "game has already been finished",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{reveal}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "game has already finished", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateLost)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "game not found",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{reveal}},
+			want: want{err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{userID: "111", gameID: "xyz", actions: []domain.Action{mark}},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, results, err := service.Act(tt.args.userID, tt.args.gameID, tt.args.actions)
+
+			assert.Equal(t, tt.want.result, result)
+			assert.Equal(t, tt.want.results, results)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
@@ -1,36 +1,134 @@
 package game
 
 import (
+	"fmt"
 	"github.com/matiasvarela/errors"
 	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
 	"github.com/matiasvarela/minesweeper-API/internal/core/port"
 	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
 	"github.com/matiasvarela/minesweeper-API/pkg/clock"
 	"github.com/matiasvarela/minesweeper-API/pkg/random"
+	"math"
+	"time"
+)
+
+const (
+	defaultNoGuessBudget  = 2 * time.Second
+	defaultAnalysisBudget = time.Second
+	defaultHintBudget     = time.Second
+	undoHistorySize       = 10
 )
 
 type service struct {
-	rnd        random.Random
-	clock      clock.Clock
-	repository port.GameRepository
+	rnd              random.Random
+	clock            clock.Clock
+	repository       port.GameRepository
+	puzzleRepository port.PuzzleRepository
+	matchRepository  port.MatchRepository
+	matchNotifier    port.MatchNotifier
+	gameNotifier     port.GameNotifier
+	noGuessBudget    time.Duration
+	analysisBudget   time.Duration
+	hintBudget       time.Duration
+	maxRows          int
+	maxColumns       int
+	maxEncodedSize   int
 }
 
-func NewService(rnd random.Random, clock clock.Clock, repository port.GameRepository) *service {
-	return &service{rnd: rnd, clock: clock, repository: repository}
+type Option func(srv *service)
+
+func NewService(rnd random.Random, clock clock.Clock, repository port.GameRepository, puzzleRepository port.PuzzleRepository, matchRepository port.MatchRepository, matchNotifier port.MatchNotifier, gameNotifier port.GameNotifier, options ...Option) *service {
+	srv := &service{
+		rnd:              rnd,
+		clock:            clock,
+		repository:       repository,
+		puzzleRepository: puzzleRepository,
+		matchRepository:  matchRepository,
+		matchNotifier:    matchNotifier,
+		gameNotifier:     gameNotifier,
+		noGuessBudget:    defaultNoGuessBudget,
+		analysisBudget:   defaultAnalysisBudget,
+		hintBudget:       defaultHintBudget,
+		maxRows:          math.MaxInt32,
+		maxColumns:       math.MaxInt32,
+		maxEncodedSize:   math.MaxInt32,
+	}
+	for _, option := range options {
+		option(srv)
+	}
+
+	return srv
 }
 
-// Get retrieves the game belonging to the userID given and with gameID given
-func (srv *service) Get(userID string, gameID string) (domain.Game, error) {
-	game, err := srv.repository.Get(userID, gameID)
-	if err != nil {
-		return domain.Game{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting game from repository")
+// WithNoGuessBudget sets the time available to find a board that can be solved without guessing
+func WithNoGuessBudget(budget time.Duration) Option {
+	return func(srv *service) {
+		srv.noGuessBudget = budget
+	}
+}
+
+// WithAnalysisBudget sets the time available to compute the probabilities of a game analysis
+func WithAnalysisBudget(budget time.Duration) Option {
+	return func(srv *service) {
+		srv.analysisBudget = budget
+	}
+}
+
+// WithHintBudget sets the time available to prove a cell safe for a hint, before falling back to the estimated risks
+func WithHintBudget(budget time.Duration) Option {
+	return func(srv *service) {
+		srv.hintBudget = budget
+	}
+}
+
+// WithMaxBoardSize sets the greatest number of rows and columns of the boards that can be created. They are not limited
+// unless it is given
+func WithMaxBoardSize(rows int, columns int) Option {
+	return func(srv *service) {
+		srv.maxRows = rows
+		srv.maxColumns = columns
 	}
+}
 
-	if game == nil {
-		return domain.Game{}, errors.New(apperrors.NotFound, nil, "game has not been found", "")
+// WithMaxEncodedSize sets the most bytes the encoded boards and mask of a game can take, so it can be stored. The size
+// is up to where the games are stored, so it is not limited unless it is given
+func WithMaxEncodedSize(size int) Option {
+	return func(srv *service) {
+		srv.maxEncodedSize = size
 	}
+}
+
+// Get retrieves the game with gameID given, as long as the userID given is its owner or one of its participants.
+// A game that has exceeded its time limit is expired and saved first. When somebody else has saved it since it was read,
+// the game is read again, since the sweeper or a participant may have already expired it
+func (srv *service) Get(userID string, gameID string) (domain.Game, error) {
+	for attempt := 1; ; attempt++ {
+		game, err := srv.repository.Get(userID, gameID)
+		if err != nil {
+			return domain.Game{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting game from repository")
+		}
+
+		if game == nil || !game.IsParticipant(userID) {
+			return domain.Game{}, errors.New(apperrors.NotFound, nil, "game has not been found", "")
+		}
+
+		if !srv.expire(game) {
+			return *game, nil
+		}
+
+		err = srv.save(game)
+		if errors.Code(err) == errors.Code(apperrors.Conflict) && attempt < maxUpdateAttempts {
+			continue
+		}
 
-	return *game, nil
+		if err != nil {
+			return domain.Game{}, errors.Wrap(err, err.Error())
+		}
+
+		srv.track(*game)
+
+		return *game, nil
+	}
 }
 
 // Get retrieves all the games belonging to the userID given
@@ -40,116 +138,498 @@ func (srv *service) GetAll(userID string) ([]domain.Game, error) {
 		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at searching games from repository")
 	}
 
+	for i := range games {
+		if !srv.expire(&games[i]) {
+			continue
+		}
+
+		err := srv.save(&games[i])
+		if errors.Code(err) == errors.Code(apperrors.Conflict) {
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, err.Error())
+		}
+
+		srv.track(games[i])
+	}
+
 	return games, nil
 }
 
-// Create creates a new game for the user and settings given
+// ExpireGames moves into the timeout state all the ongoing games whose time limit has been exceeded
+func (srv *service) ExpireGames() error {
+	games, err := srv.repository.GetAllTimed()
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at searching timed games from repository")
+	}
+
+	for i := range games {
+		if !srv.expire(&games[i]) {
+			continue
+		}
+
+		err := srv.save(&games[i])
+		if errors.Code(err) == errors.Code(apperrors.Conflict) {
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrap(err, err.Error())
+		}
+
+		srv.track(games[i])
+	}
+
+	return nil
+}
+
+// Create creates a new game for the user and settings given. When the settings refer to a puzzle, the game is played
+// on the board of the puzzle, with its mines already placed
 func (srv *service) Create(userID string, settings domain.GameSettings) (domain.Game, error) {
-	game := domain.Game{
-		ID:       srv.rnd.GenerateID(),
-		UserID:   userID,
-		Settings: settings,
-		Board:    domain.NewEmptyBoard(settings.Rows, settings.Columns),
-		State:    domain.GameStateNew,
+	game, err := srv.newGame(userID, settings)
+	if err != nil {
+		return domain.Game{}, errors.Wrap(err, err.Error())
 	}
 
-	if err := srv.repository.Save(game); err != nil {
-		return domain.Game{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving game into repository")
+	if err := srv.save(&game); err != nil {
+		return domain.Game{}, errors.Wrap(err, err.Error())
 	}
 
 	return game, nil
 }
 
-// MarkCell mark/unmark the given cell with a flag
-func (srv *service) MarkCell(userID string, gameID string, row int, column int) (domain.Game, error) {
-	game, err := srv.Get(userID, gameID)
+// newGame validates the settings given and builds a new game for the user, without saving it
+func (srv *service) newGame(userID string, settings domain.GameSettings) (domain.Game, error) {
+	var puzzle *domain.Puzzle
+	if settings.PuzzleID != "" {
+		var err error
+		if puzzle, err = srv.puzzleRepository.Get(settings.PuzzleID); err != nil {
+			return domain.Game{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting puzzle from repository")
+		}
+
+		if puzzle == nil {
+			return domain.Game{}, errors.New(apperrors.NotFound, nil, "puzzle has not been found", "")
+		}
+
+		settings.Rows, settings.Columns, settings.BombsNumber = puzzle.Rows, puzzle.Columns, puzzle.BombsNumber
+		settings.Topology, settings.Mask = puzzle.Topology, domain.Mask{}
+		settings.NoGuess, settings.FirstClick = false, domain.FirstClickNone
+	}
+
+	if settings.Rows <= 0 || settings.Rows > srv.maxRows {
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the number of rows must be between 1 and %d", srv.maxRows), "")
+	}
+
+	if settings.Columns <= 0 || settings.Columns > srv.maxColumns {
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the number of columns must be between 1 and %d", srv.maxColumns), "")
+	}
+
+	board, err := newBoard(settings)
 	if err != nil {
 		return domain.Game{}, errors.Wrap(err, err.Error())
 	}
 
-	pos := domain.NewPosition(row, column)
+	if size := settings.EncodedSize(undoHistorySize); size > srv.maxEncodedSize {
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the game is too big to be stored: its boards and mask would take up to %d bytes and only %d are allowed, try fewer cells", size, srv.maxEncodedSize), "")
+	}
 
-	if !game.Board.IsValidPosition(pos) {
-		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, "invalid row and column parameters", "")
-	}
-
-	switch game.Board.Get(pos) {
-	case domain.EmptyCellCovered:
-		game.Board.Set(pos, domain.EmptyCellCoveredAndMarked)
-	case domain.EmptyCellCoveredAndMarked:
-		game.Board.Set(pos, domain.EmptyCellCovered)
-	case domain.BombCellCovered:
-		game.Board.Set(pos, domain.BombCellCoveredAndMarked)
-	case domain.BombCellCoveredAndMarked:
-		game.Board.Set(pos, domain.BombCellCovered)
+	if settings.BombsNumber < 0 || settings.BombsNumber >= board.CountEnabled() {
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, "the number of bombs must be less than the number of cells", "")
+	}
+
+	if settings.NoGuessBudget < 0 || time.Duration(settings.NoGuessBudget)*time.Millisecond > srv.noGuessBudget {
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the no guess budget must be between 0 and %d milliseconds", srv.noGuessBudget.Milliseconds()), "")
+	}
+
+	switch settings.FirstClick {
+	case "", domain.FirstClickNeighborhood, domain.FirstClickCell, domain.FirstClickNone:
 	default:
-		return game, nil
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, "invalid first click protection", "")
+	}
+
+	if _, ok := domain.NewTopology(settings.Topology); !ok {
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, "invalid topology", "")
 	}
 
-	if err := srv.repository.Save(game); err != nil {
-		return domain.Game{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving game into repository")
+	game := domain.Game{
+		ID:             srv.rnd.GenerateID(),
+		UserID:         userID,
+		Settings:       settings,
+		Board:          board,
+		State:          domain.GameStateNew,
+		RemainingLives: settings.Lives,
+	}
+
+	if game.RemainingLives <= 0 {
+		game.RemainingLives = 1
+	}
+
+	if puzzle != nil {
+		game.Board = puzzle.Board.Layout()
+		game.Metrics = game.Board.Metrics(game.Topology())
 	}
 
 	return game, nil
 }
 
-// RevealCell reveals the given cell and will reveal recursively the adjacent cells if there is no bomb as neighbor
-func (srv *service) RevealCell(userID string, gameID string, row int, column int) (domain.Game, error) {
-	game, err := srv.Get(userID, gameID)
+// newBoard returns an empty board of the size given in the settings with the cells disabled by its mask
+func newBoard(settings domain.GameSettings) (domain.Board, error) {
+	board := domain.NewEmptyBoard(settings.Rows, settings.Columns)
+
+	if len(settings.Mask.Layout) > 0 && len(settings.Mask.Layout) != settings.Rows {
+		return nil, errors.New(apperrors.InvalidInput, nil, "the mask layout must have a line per row", "")
+	}
+
+	for i, line := range settings.Mask.Layout {
+		if len([]rune(line)) != settings.Columns {
+			return nil, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("line %d of the mask layout must have a character per column", i), "")
+		}
+
+		for _, c := range line {
+			if c != domain.MaskLayoutEnabled && c != domain.MaskLayoutDisabled {
+				return nil, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("line %d of the mask layout has an invalid character", i), "")
+			}
+		}
+	}
+
+	for i, pos := range settings.Mask.Cells {
+		if !board.IsValidPosition(pos) {
+			return nil, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("invalid row and column parameters of mask cell %d", i), "")
+		}
+	}
+
+	board.Disable(settings.Mask.Disabled())
+
+	return board, nil
+}
+
+// Retry creates a new game with the same settings and bombs as the given finished game, so the board can be played again
+func (srv *service) Retry(userID string, gameID string) (domain.Game, error) {
+	source, err := srv.Get(userID, gameID)
 	if err != nil {
 		return domain.Game{}, errors.Wrap(err, err.Error())
 	}
 
-	if game.State == domain.GameStateLost || game.State == domain.GameStateWon {
-		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, "game has already finished", "")
+	if !source.IsFinished() {
+		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, "game has not finished yet", "")
 	}
 
-	pos := domain.NewPosition(row, column)
+	game := domain.Game{
+		ID:             srv.rnd.GenerateID(),
+		UserID:         userID,
+		SourceGameID:   source.ID,
+		Settings:       source.Settings,
+		Board:          source.Board.Layout(),
+		State:          domain.GameStateNew,
+		RemainingLives: source.Settings.Lives,
+		Metrics:        source.Metrics,
+	}
+
+	// the bombs are already placed, so they cannot keep away from the first click nor be placed again to avoid guesses
+	game.Settings.NoGuess, game.Settings.FirstClick = false, domain.FirstClickNone
+
+	if game.RemainingLives <= 0 {
+		game.RemainingLives = 1
+	}
+
+	if err := srv.save(&game); err != nil {
+		return domain.Game{}, errors.Wrap(err, err.Error())
+	}
+
+	return game, nil
+}
+
+// MarkCell moves the given cell to its next mark: covered, flag and, when the game allows question marks, question.
+// It also returns the cells that have changed
+func (srv *service) MarkCell(userID string, gameID string, coordinates domain.Coordinates) (domain.Game, []domain.Position, error) {
+	var changed []domain.Position
+
+	game, err := srv.update(userID, gameID, func(game *domain.Game) (bool, error) {
+		if game.IsFinished() {
+			return false, errors.New(apperrors.InvalidInput, nil, "game has already finished", "")
+		}
+
+		pos, err := locate(*game, coordinates)
+		if err != nil {
+			return false, errors.Wrap(err, err.Error())
+		}
+
+		changed = srv.mark(game, pos)
+		if len(changed) == 0 {
+			return false, nil
+		}
+
+		record(game, userID, domain.Action{Type: domain.ActionMark, Coordinates: domain.NewOffsetCoordinates(pos.Row, pos.Column)})
+
+		return true, nil
+	})
+	if err != nil {
+		return domain.Game{}, nil, errors.Wrap(err, err.Error())
+	}
+
+	if len(changed) == 0 {
+		return game, nil, nil
+	}
+
+	return game, changed, nil
+}
+
+// locate returns the position of the cell of the game at the given coordinates, which must be in a coordinate system
+// the topology of the game supports
+func locate(game domain.Game, coordinates domain.Coordinates) (domain.Position, error) {
+	pos, ok := coordinates.Position(game.Topology())
+	if !ok {
+		return domain.Position{}, errors.New(apperrors.InvalidInput, nil, "axial coordinates can only be used in hexagonal boards", "")
+	}
 
 	if !game.Board.IsValidPosition(pos) {
-		return domain.Game{}, errors.New(apperrors.InvalidInput, nil, "invalid row and column parameters", "")
+		return domain.Position{}, errors.New(apperrors.InvalidInput, nil, "invalid row and column parameters", "")
 	}
 
-	switch game.Board.Get(pos) {
-	case domain.EmptyCellCovered:
-		if game.State == domain.GameStateNew {
-			srv.startGame(&game, pos)
-			break
+	return pos, nil
+}
+
+// RevealCell reveals the given cell and will reveal recursively the adjacent cells if there is no bomb as neighbor.
+// Revealing a bomb consumes a life and the game is lost when no lives remain. It also returns the cells that have changed
+func (srv *service) RevealCell(userID string, gameID string, coordinates domain.Coordinates) (domain.Game, []domain.Position, error) {
+	var changed []domain.Position
+
+	game, err := srv.update(userID, gameID, func(game *domain.Game) (bool, error) {
+		if game.IsFinished() {
+			return false, errors.New(apperrors.InvalidInput, nil, "game has already finished", "")
 		}
 
-		srv.revealInCascade(&game, pos)
+		pos, err := locate(*game, coordinates)
+		if err != nil {
+			return false, errors.Wrap(err, err.Error())
+		}
 
-		if game.Board.Count(domain.EmptyCellRevealed) == game.Settings.Rows*game.Settings.Columns-game.Settings.BombsNumber {
-			game.State = domain.GameStateWon
-			game.EndedAt = srv.clock.Now()
+		changed = srv.reveal(game, pos)
+		if len(changed) == 0 {
+			return false, nil
 		}
-	case domain.BombCellCovered:
-		game.Board.Set(pos, domain.BombCellRevealed)
-		game.State = domain.GameStateLost
-		game.EndedAt = srv.clock.Now()
-	default:
-		return game, nil
+
+		record(game, userID, domain.Action{Type: domain.ActionReveal, Coordinates: domain.NewOffsetCoordinates(pos.Row, pos.Column)})
+
+		return true, nil
+	})
+	if err != nil {
+		return domain.Game{}, nil, errors.Wrap(err, err.Error())
 	}
 
-	if err := srv.repository.Save(game); err != nil {
-		return domain.Game{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving game into repository")
+	if len(changed) == 0 {
+		return game, nil, nil
+	}
+
+	return game, changed, nil
+}
+
+// Hint suggests the next move for the given game and counts it as a hint used
+func (srv *service) Hint(userID string, gameID string) (domain.Game, domain.Hint, error) {
+	var hint domain.Hint
+
+	game, err := srv.update(userID, gameID, func(game *domain.Game) (bool, error) {
+		if game.IsFinished() {
+			return false, errors.New(apperrors.InvalidInput, nil, "game has already finished", "")
+		}
+
+		if game.MatchID != "" {
+			return false, errors.New(apperrors.InvalidInput, nil, "match games cannot get hints", "")
+		}
+
+		ok := true
+		hint = srv.firstHint(*game)
+		if game.State != domain.GameStateNew {
+			deadline := srv.clock.Now().Add(srv.hintBudget)
+			hint, ok = domain.NewHint(game.Board, game.Topology(), game.Settings.BombsNumber, func() bool {
+				return !srv.clock.Now().Before(deadline)
+			})
+		}
+
+		if !ok {
+			return false, errors.New(apperrors.InvalidInput, nil, "there is no cell left to reveal", "")
+		}
+
+		game.HintsUsed++
+
+		return true, nil
+	})
+	if err != nil {
+		return domain.Game{}, domain.Hint{}, errors.Wrap(err, err.Error())
+	}
+
+	return game, hint, nil
+}
+
+// Undo restores the given practice game to the state it had before the last reveal or mark, even if that move lost it
+func (srv *service) Undo(userID string, gameID string) (domain.Game, error) {
+	game, err := srv.update(userID, gameID, func(game *domain.Game) (bool, error) {
+		if !game.Settings.Practice {
+			return false, errors.New(apperrors.InvalidInput, nil, "undo is only available for practice games", "")
+		}
+
+		if !game.Undo() {
+			return false, errors.New(apperrors.InvalidInput, nil, "there is no move to undo", "")
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return domain.Game{}, errors.Wrap(err, err.Error())
 	}
 
 	return game, nil
 }
 
+// Analyze computes the probability of having a bomb of every covered cell of the given game.
+// It is only available for finished games or practice games, since otherwise it would help the player too much
+func (srv *service) Analyze(userID string, gameID string) (domain.Analysis, error) {
+	game, err := srv.Get(userID, gameID)
+	if err != nil {
+		return domain.Analysis{}, errors.Wrap(err, err.Error())
+	}
+
+	if !game.IsFinished() && !game.Settings.Practice {
+		return domain.Analysis{}, errors.New(apperrors.InvalidInput, nil, "analysis is only available for finished or practice games", "")
+	}
+
+	return domain.Analyze(game.Board, game.Topology(), game.Settings.BombsNumber, srv.clock.Now, srv.analysisBudget, srv.rnd.Intn), nil
+}
+
+// firstHint suggests the center of the board, or the next cell not disabled by the mask, for a game that has not
+// started. It is only safe if the first click is protected, otherwise its risk is the density of the bombs
+func (srv *service) firstHint(game domain.Game) domain.Hint {
+	cells := game.Settings.Rows * game.Settings.Columns
+	center := game.Settings.Rows/2*game.Settings.Columns + game.Settings.Columns/2
+
+	var pos domain.Position
+	for i := 0; i < cells; i++ {
+		v := (center + i) % cells
+		pos = domain.NewPosition(v/game.Settings.Columns, v%game.Settings.Columns)
+		if !game.Board.Is(pos, domain.DisabledCell) {
+			break
+		}
+	}
+
+	if game.Settings.FirstClick == domain.FirstClickNone || hasBombsPlaced(game) {
+		reason := "the bombs are placed when the first cell is revealed and the first click is not protected"
+		if hasBombsPlaced(game) {
+			reason = "the bombs have already been placed, so the first click is not protected"
+		}
+
+		return domain.Hint{
+			Position:    pos,
+			Kind:        domain.HintGuess,
+			Reason:      reason,
+			Probability: float64(game.Settings.BombsNumber) / float64(game.Board.CountEnabled()),
+		}
+	}
+
+	return domain.Hint{Position: pos, Kind: domain.HintSafe, Reason: "the first revealed cell never has a bomb"}
+}
+
+// hasBombsPlaced returns true for the games whose bombs are placed before they start: retried, puzzle and match games
+func hasBombsPlaced(game domain.Game) bool {
+	return game.SourceGameID != "" || game.Settings.PuzzleID != "" || game.MatchID != ""
+}
+
+// remember keeps the state of practice games before a move, so it can be undone
+func remember(game *domain.Game) {
+	if game.Settings.Practice {
+		game.Remember(undoHistorySize)
+	}
+}
+
+// expire moves the game into the timeout state if its time limit has been exceeded; returns true if the game has expired
+func (srv *service) expire(game *domain.Game) bool {
+	deadline, ok := game.Deadline()
+	if !ok || !srv.clock.Now().After(deadline) {
+		return false
+	}
+
+	game.State = domain.GameStateTimeout
+	game.EndedAt = deadline
+
+	return true
+}
+
+// startGame places the bombs keeping away from the first revealed cell as much as the first click protection requires
+// and rates the resulting board. Retried, puzzle and match games already have their bombs, so they are kept where they are
 func (srv *service) startGame(game *domain.Game, pos domain.Position) {
 	game.State = domain.GameStateOnGoing
 	game.StartedAt = srv.clock.Now()
-	game.Board.Set(pos, domain.EmptyCellRevealed)
 
-	srv.fillBoardWithBombs(game, pos)
+	if hasBombsPlaced(*game) {
+		return
+	}
+
+	var exclude []domain.Position
+	switch game.Settings.FirstClick {
+	case domain.FirstClickNone:
+	case domain.FirstClickCell:
+		exclude = []domain.Position{pos}
+	default:
+		exclude = append(game.Board.Neighbors(game.Topology(), pos), pos)
+		if game.Board.CountEnabled()-len(exclude) < game.Settings.BombsNumber {
+			exclude = []domain.Position{pos}
+		}
+	}
+
+	if game.Settings.NoGuess {
+		srv.fillBoardWithBombsWithoutGuessing(game, pos, exclude)
+	} else {
+		srv.fillBoardWithBombs(game, exclude)
+	}
+
+	game.Metrics = game.Board.Metrics(game.Topology())
 }
 
-func (srv *service) fillBoardWithBombs(game *domain.Game, exclude domain.Position) {
+// fillBoardWithBombsWithoutGuessing places the bombs again and again until the board can be solved without guessing
+// from the given position. When the time budget of the game runs out, even in the middle of solving a layout, it falls
+// back to the layout that left fewer cells to guess
+func (srv *service) fillBoardWithBombsWithoutGuessing(game *domain.Game, start domain.Position, exclude []domain.Position) {
+	budget := srv.noGuessBudget
+	if game.Settings.NoGuessBudget > 0 {
+		budget = time.Duration(game.Settings.NoGuessBudget) * time.Millisecond
+	}
+
+	deadline := srv.clock.Now().Add(budget)
+	expired := func() bool {
+		return !srv.clock.Now().Before(deadline)
+	}
+
+	var best domain.Board
+	bestUnsolvable := -1
+
+	for {
+		candidate := *game
+		candidate.Board = game.Board.Copy()
+		srv.fillBoardWithBombs(&candidate, exclude)
+
+		unsolvable := candidate.Board.CountUnsolvable(game.Topology(), start, game.Settings.BombsNumber, expired)
+		if bestUnsolvable < 0 || unsolvable < bestUnsolvable {
+			best, bestUnsolvable = candidate.Board, unsolvable
+		}
+
+		if bestUnsolvable == 0 || expired() {
+			break
+		}
+	}
+
+	game.Board = best
+}
+
+func (srv *service) fillBoardWithBombs(game *domain.Game, exclude []domain.Position) {
 	var row, column int
 	var bomb domain.Position
 
+	excluded := map[domain.Position]bool{}
+	for _, pos := range exclude {
+		excluded[pos] = true
+	}
+
 	count := 0
 	for _, v := range srv.rnd.GenerateN(game.Settings.Rows * game.Settings.Columns) {
 		if count == game.Settings.BombsNumber {
@@ -160,24 +640,11 @@ func (srv *service) fillBoardWithBombs(game *domain.Game, exclude domain.Positio
 		column = v - row*game.Settings.Columns
 
 		bomb = domain.NewPosition(row, column)
-		if bomb == exclude {
+		if excluded[bomb] || game.Board.Is(bomb, domain.DisabledCell) {
 			continue
 		}
 
-		game.Board.Set(bomb, domain.BombCellCovered)
+		game.Board.PlaceBomb(bomb)
 		count++
 	}
 }
-
-func (srv *service) revealInCascade(game *domain.Game, pos domain.Position) {
-	switch game.Board.Get(pos) {
-	case domain.EmptyCellCovered:
-		game.Board.Set(pos, domain.EmptyCellRevealed)
-	}
-
-	for _, neighbor := range game.Board.GetNeighborsIfNoBombs(pos) {
-		srv.revealInCascade(game, neighbor)
-	}
-
-	return
-}
@@ -15,25 +15,40 @@ import (
 var (
 	e = domain.EmptyCellCovered
 	X = domain.EmptyCellCoveredAndMarked
+	Q = domain.EmptyCellCoveredAndQuestioned
 	E = domain.EmptyCellRevealed
 
 	b = domain.BombCellCovered
 	Y = domain.BombCellCoveredAndMarked
 	B = domain.BombCellRevealed
+
+	D = domain.DisabledCell
 )
 
 type dep struct {
 	rnd        *mock.MockRandom
 	clock      *mock.MockClock
 	repository *mock.MockGameRepository
+	puzzles    *mock.MockPuzzleRepository
+	matches    *mock.MockMatchRepository
+	notifier   *mock.MockMatchNotifier
+	games      *mock.MockGameNotifier
 }
 
+// newDep returns the mocked dependencies of the service. The games saved may be published to their spectators at any time
 func newDep(t *testing.T) dep {
-	return dep{
+	dep := dep{
 		rnd:        mock.NewMockRandom(gomock.NewController(t)),
 		clock:      mock.NewMockClock(gomock.NewController(t)),
 		repository: mock.NewMockGameRepository(gomock.NewController(t)),
+		puzzles:    mock.NewMockPuzzleRepository(gomock.NewController(t)),
+		matches:    mock.NewMockMatchRepository(gomock.NewController(t)),
+		notifier:   mock.NewMockMatchNotifier(gomock.NewController(t)),
+		games:      mock.NewMockGameNotifier(gomock.NewController(t)),
 	}
+	dep.games.EXPECT().Publish(gomock.Any()).AnyTimes()
+
+	return dep
 }
 
 func TestService_Get(t *testing.T) {
@@ -60,6 +75,23 @@ func TestService_Get(t *testing.T) {
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&want.result, nil)
 			},
 		},
+		{
+			name: "get shared game as one of its participants",
+			args: args{userID: "222", gameID: "xyz"},
+			want: want{result: MockGame("111", "xyz", "", withParticipants("222"))},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&want.result, nil)
+			},
+		},
+		{
+			name: "game of other user is not found",
+			args: args{userID: "333", gameID: "xyz"},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", "", withParticipants("222"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
 		{
 			name: "game not found",
 			args: args{gameID: "any"},
@@ -76,6 +108,53 @@ func TestService_Get(t *testing.T) {
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, apperrors.Internal)
 			},
 		},
+		{
+			name: "get timed game within its time limit",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})},
+			mock: func(dep dep, args args, want want) {
+				game := MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})
+				dep.clock.EXPECT().Now().Return(game.StartedAt.Add(30 * time.Second))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "get timed game that has exceeded its time limit",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: MockTimedGame("111", "xyz", domain.GameStateTimeout, mockedStartedAt.Add(time.Minute), withVersion(1))},
+			mock: func(dep dep, args args, want want) {
+				game := MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})
+				dep.clock.EXPECT().Now().Return(game.StartedAt.Add(2 * time.Minute))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "get timed game expired by somebody else while it was being expired",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: MockTimedGame("111", "xyz", domain.GameStateTimeout, mockedStartedAt.Add(time.Minute), withVersion(1))},
+			mock: func(dep dep, args args, want want) {
+				game := MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})
+				expired := want.result
+				dep.clock.EXPECT().Now().Return(game.StartedAt.Add(2 * time.Minute))
+				gomock.InOrder(
+					dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil),
+					dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&expired, nil),
+				)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(errors.New(apperrors.Conflict, nil, "the game has been changed concurrently", ""))
+			},
+		},
+		{
+			name: "fail at save expired game into repository",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				game := MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})
+				dep.clock.EXPECT().Now().Return(game.StartedAt.Add(2 * time.Minute))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -83,7 +162,7 @@ func TestService_Get(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			dep := newDep(t)
-			service := game.NewService(dep.rnd, dep.clock, dep.repository)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
 			tt.mock(dep, tt.args, tt.want)
 			result, err := service.Get(tt.args.userID, tt.args.gameID)
 
@@ -134,7 +213,7 @@ func TestService_GetAll(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			dep := newDep(t)
-			service := game.NewService(dep.rnd, dep.clock, dep.repository)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
 			tt.mock(dep, tt.args, tt.want)
 			result, err := service.GetAll(tt.args.userID)
 
@@ -147,16 +226,74 @@ func TestService_GetAll(t *testing.T) {
 	}
 }
 
+func TestService_ExpireGames(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(dep, want)
+	}{
+		{
+			name: "expire games successfully",
+			want: want{},
+			mock: func(dep dep, want want) {
+				expired := MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})
+				alive := MockTimedGame("111", "abc", domain.GameStateOnGoing, time.Time{})
+				alive.StartedAt = alive.StartedAt.Add(time.Minute)
+
+				dep.clock.EXPECT().Now().Return(mockedStartedAt.Add(90 * time.Second)).Times(2)
+				dep.repository.EXPECT().GetAllTimed().Return([]domain.Game{expired, alive}, nil)
+				dep.repository.EXPECT().Save(MockTimedGame("111", "xyz", domain.GameStateTimeout, mockedStartedAt.Add(time.Minute), withVersion(1))).Return(nil)
+			},
+		},
+		{
+			name: "fail at get timed games from repository",
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at searching timed games from repository")},
+			mock: func(dep dep, want want) {
+				dep.repository.EXPECT().GetAllTimed().Return(nil, apperrors.Internal)
+			},
+		},
+		{
+			name: "fail at save expired game into repository",
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, want want) {
+				dep.clock.EXPECT().Now().Return(mockedStartedAt.Add(2 * time.Minute))
+				dep.repository.EXPECT().GetAllTimed().Return([]domain.Game{MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})}, nil)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.want)
+			err := service.ExpireGames()
+
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
 func TestService_MarkCell(t *testing.T) {
 	type args struct {
-		userID string
-		gameID string
-		row    int
-		column int
+		userID      string
+		gameID      string
+		coordinates domain.Coordinates
 	}
 	type want struct {
-		result domain.Game
-		err    error
+		result  domain.Game
+		changed []domain.Position
+		err     error
 	}
 
 	tests := []struct {
@@ -167,8 +304,8 @@ func TestService_MarkCell(t *testing.T) {
 	}{
 		{
 			name: "mark - empty covered cell",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
-			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCoveredAndMarked)},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCoveredAndMarked, withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCovered)
 
@@ -178,8 +315,8 @@ func TestService_MarkCell(t *testing.T) {
 		},
 		{
 			name: "unmark - marked empty covered cell",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
-			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCovered)},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCovered, withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCoveredAndMarked)
 
@@ -189,8 +326,8 @@ func TestService_MarkCell(t *testing.T) {
 		},
 		{
 			name: "mark - cell bomb covered",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
-			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCoveredAndMarked)},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCoveredAndMarked, withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCovered)
 
@@ -200,8 +337,8 @@ func TestService_MarkCell(t *testing.T) {
 		},
 		{
 			name: "unmark - marked cell bomb covered",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
-			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCovered)},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCovered, withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCoveredAndMarked)
 
@@ -209,10 +346,54 @@ func TestService_MarkCell(t *testing.T) {
 				dep.repository.EXPECT().Save(want.result).Return(nil)
 			},
 		},
+		{
+			name: "question - marked empty covered cell",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCoveredAndQuestioned, withQuestionMarks(), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCoveredAndMarked, withQuestionMarks())
+
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "unmark - questioned empty covered cell",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCovered, withQuestionMarks(), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.EmptyCellCoveredAndQuestioned, withQuestionMarks())
+
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "question - marked cell bomb covered",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCoveredAndQuestioned, withQuestionMarks(), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCoveredAndMarked, withQuestionMarks())
+
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "unmark - questioned cell bomb covered",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCovered, withQuestionMarks(), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(1, 1)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellCoveredAndQuestioned, withQuestionMarks())
+
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
 		{
 			name: "mark - revealed empty cell",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
-			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellRevealed)},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellRevealed), changed: []domain.Position{}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithCell("111", "xyz", "", 1, 1, domain.BombCellRevealed)
 
@@ -220,9 +401,18 @@ func TestService_MarkCell(t *testing.T) {
 				dep.repository.EXPECT().Save(want.result).Return(nil)
 			},
 		},
+		{
+			name: "game has already been finished",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "game has already finished", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", domain.GameStateLost, 1, 1, domain.BombCellCovered)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
 		{
 			name: "game not found",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
 			mock: func(dep dep, args args, want want) {
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, nil)
@@ -230,17 +420,17 @@ func TestService_MarkCell(t *testing.T) {
 		},
 		{
 			name: "fail at save into repository",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
 			mock: func(dep dep, args args, want want) {
 				game := MockGame(args.userID, args.gameID, "")
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
-				dep.repository.EXPECT().Save(game).Return(apperrors.Internal)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
 			},
 		},
 		{
 			name: "invalid row and column params",
-			args: args{userID: "111", gameID: "xyz", row: -100, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(-100, 1)},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, apperrors.InvalidInput, "invalid row and column parameters", "")},
 			mock: func(dep dep, args args, want want) {
 				game := MockGame(args.userID, args.gameID, "")
@@ -254,11 +444,12 @@ func TestService_MarkCell(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			dep := newDep(t)
-			service := game.NewService(dep.rnd, dep.clock, dep.repository)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
 			tt.mock(dep, tt.args, tt.want)
-			result, err := service.MarkCell(tt.args.userID, tt.args.gameID, tt.args.row, tt.args.column)
+			result, changed, err := service.MarkCell(tt.args.userID, tt.args.gameID, tt.args.coordinates)
 
 			assert.Equal(t, tt.want.result, result)
+			assert.ElementsMatch(t, tt.want.changed, changed)
 			if err != nil && tt.want.err != nil {
 				assert.Equal(t, tt.want.err.Error(), err.Error())
 			}
@@ -277,6 +468,29 @@ func TestService_Create(t *testing.T) {
 		err    error
 	}
 
+	maskedGame := MockGameWithBoard("111", "xyz", domain.GameStateNew, 2, domain.Board{
+		{D, e, e, D},
+		{e, D, e, e},
+		{D, e, e, D},
+	}, time.Time{}, time.Time{}, withMask(domain.Mask{
+		Cells:  []domain.Position{{Row: 1, Column: 1}},
+		Layout: []string{".##.", "####", ".##."},
+	}), withVersion(1))
+	maskedGame.RemainingLives = 1
+
+	puzzle := domain.Puzzle{ID: "abc", AuthorID: "222", Rows: 2, Columns: 3, BombsNumber: 2, Board: domain.Board{
+		{b, e, D},
+		{e, e, b},
+	}}
+	puzzleGame := MockGameWithBoard("111", "xyz", domain.GameStateNew, 2, domain.Board{
+		{b, e, D},
+		{e, e, b},
+	}, time.Time{}, time.Time{}, withVersion(1))
+	puzzleGame.Settings.PuzzleID = "abc"
+	puzzleGame.Settings.FirstClick = domain.FirstClickNone
+	puzzleGame.RemainingLives = 1
+	puzzleGame.Metrics = puzzleGame.Board.Metrics(domain.StandardTopology)
+
 	tests := []struct {
 		name string
 		args args
@@ -286,19 +500,138 @@ func TestService_Create(t *testing.T) {
 		{
 			name: "create game successfully",
 			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10}},
-			want: want{result: MockGame("111", "xyz", domain.GameStateNew)},
+			want: want{result: MockGame("111", "xyz", domain.GameStateNew, withVersion(1))},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return(want.result.ID)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "create game with lives successfully",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10, Lives: 3}},
+			want: want{result: MockGame("111", "xyz", domain.GameStateNew, withLives(3, 3), withVersion(1))},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return(want.result.ID)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "invalid number of rows",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 0, Columns: 6, BombsNumber: 10}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "the number of rows must be between 1 and 800", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid number of columns",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 801, BombsNumber: 10}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "the number of columns must be between 1 and 800", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "game too big to be stored",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 800, Columns: 800, BombsNumber: 10, Practice: true}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "the game is too big to be stored: its boards and mask would take up to 3520099 bytes and only 358400 are allowed, try fewer cells", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid number of bombs",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 36}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "the number of bombs must be less than the number of cells", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid no guess budget",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10, NoGuess: true, NoGuessBudget: 2001}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "the no guess budget must be between 0 and 2000 milliseconds", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid first click protection",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10, FirstClick: "corner"}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "invalid first click protection", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "create game with mask successfully",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 3, Columns: 4, BombsNumber: 2, Mask: domain.Mask{
+				Cells:  []domain.Position{{Row: 1, Column: 1}},
+				Layout: []string{".##.", "####", ".##."},
+			}}},
+			want: want{result: maskedGame},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return(want.result.ID)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "invalid number of lines of the mask layout",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 3, Columns: 4, BombsNumber: 2, Mask: domain.Mask{Layout: []string{".##.", "####"}}}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "the mask layout must have a line per row", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid length of a line of the mask layout",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 3, Columns: 4, BombsNumber: 2, Mask: domain.Mask{Layout: []string{".##.", "###", ".##."}}}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "line 1 of the mask layout must have a character per column", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid character of the mask layout",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 3, Columns: 4, BombsNumber: 2, Mask: domain.Mask{Layout: []string{".##.", "####", ".#x."}}}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "line 2 of the mask layout has an invalid character", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid cell of the mask",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 3, Columns: 4, BombsNumber: 2, Mask: domain.Mask{Cells: []domain.Position{{Row: 0, Column: 0}, {Row: 3, Column: 0}}}}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "invalid row and column parameters of mask cell 1", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid number of bombs for the cells left by the mask",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 3, Columns: 4, BombsNumber: 8, Mask: domain.Mask{Layout: []string{".##.", "####", ".##."}}}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "the number of bombs must be less than the number of cells", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid topology",
+			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10, Topology: "sphere"}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "invalid topology", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "create game from a puzzle successfully",
+			args: args{userID: "111", settings: domain.GameSettings{PuzzleID: "abc", Rows: 9, BombsNumber: 1, NoGuess: true}},
+			want: want{result: puzzleGame},
 			mock: func(dep dep, args args, want want) {
+				dep.puzzles.EXPECT().Get("abc").Return(&puzzle, nil)
 				dep.rnd.EXPECT().GenerateID().Return(want.result.ID)
 				dep.repository.EXPECT().Save(want.result).Return(nil)
 			},
 		},
+		{
+			name: "puzzle not found",
+			args: args{userID: "111", settings: domain.GameSettings{PuzzleID: "abc"}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.NotFound, nil, "puzzle has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.puzzles.EXPECT().Get("abc").Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at get puzzle from repository",
+			args: args{userID: "111", settings: domain.GameSettings{PuzzleID: "abc"}},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting puzzle from repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.puzzles.EXPECT().Get("abc").Return(nil, apperrors.Internal)
+			},
+		},
 		{
 			name: "fail at save in repository",
 			args: args{userID: "111", settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10}},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
 			mock: func(dep dep, args args, want want) {
 				dep.rnd.EXPECT().GenerateID().Return("xyz")
-				dep.repository.EXPECT().Save(MockGame(args.userID, "xyz", "")).Return(apperrors.Internal)
+				dep.repository.EXPECT().Save(MockGame(args.userID, "xyz", "", withVersion(1))).Return(apperrors.Internal)
 			},
 		},
 	}
@@ -308,7 +641,7 @@ func TestService_Create(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			dep := newDep(t)
-			service := game.NewService(dep.rnd, dep.clock, dep.repository)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games, game.WithMaxBoardSize(800, 800), game.WithMaxEncodedSize(350*1024))
 			tt.mock(dep, tt.args, tt.want)
 			result, err := service.Create(tt.args.userID, tt.args.settings)
 
@@ -325,14 +658,14 @@ func TestService_RevealCell(t *testing.T) {
 	mockedTime, _ := time.Parse(time.RFC3339, time.RFC3339)
 
 	type args struct {
-		userID string
-		gameID string
-		row    int
-		column int
+		userID      string
+		gameID      string
+		coordinates domain.Coordinates
 	}
 	type want struct {
-		result domain.Game
-		err    error
+		result  domain.Game
+		changed []domain.Position
+		err     error
 	}
 
 	tests := []struct {
@@ -343,7 +676,7 @@ func TestService_RevealCell(t *testing.T) {
 	}{
 		{
 			name: "game not found",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
 			mock: func(dep dep, args args, want want) {
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, nil)
@@ -351,7 +684,7 @@ func TestService_RevealCell(t *testing.T) {
 		},
 		{
 			name: "game has already been finished - lost",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "game has already finished", "")},
 			mock: func(dep dep, args args, want want) {
 				game := MockGame("111", "xyz", domain.GameStateLost)
@@ -360,16 +693,27 @@ func TestService_RevealCell(t *testing.T) {
 		},
 		{
 			name: "game has already been finished - won",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "game has already finished", "")},
 			mock: func(dep dep, args args, want want) {
 				game := MockGame("111", "xyz", domain.GameStateWon)
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
 			},
 		},
+		{
+			name: "game has already been finished - time limit exceeded",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "game has already finished", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockTimedGame("111", "xyz", domain.GameStateOnGoing, time.Time{})
+				dep.clock.EXPECT().Now().Return(game.StartedAt.Add(2 * time.Minute))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(MockTimedGame("111", "xyz", domain.GameStateTimeout, game.StartedAt.Add(time.Minute), withVersion(1))).Return(nil)
+			},
+		},
 		{
 			name: "invalid position",
-			args: args{userID: "111", gameID: "xyz", row: -100, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(-100, 1)},
 			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "invalid row and column parameters", "")},
 			mock: func(dep dep, args args, want want) {
 				game := MockGame("111", "xyz", domain.GameStateNew)
@@ -378,7 +722,7 @@ func TestService_RevealCell(t *testing.T) {
 		},
 		{
 			name: "cell is marked",
-			args: args{gameID: "xyz", row: 1, column: 1},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
 			want: want{result: MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 1, 1, domain.EmptyCellCoveredAndMarked)},
 			mock: func(dep dep, args args, want want) {
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&want.result, nil)
@@ -386,13 +730,17 @@ func TestService_RevealCell(t *testing.T) {
 		},
 		{
 			name: "reveal first cell successfully",
-			args: args{userID: "111", gameID: "xyz", row: 2, column: 2},
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 2)},
 			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 5, domain.Board{
-				{b, e, b, e, e, e},
-				{e, b, e, e, e, e},
-				{e, e, E, e, b, e},
-				{e, e, e, e, e, b},
-			}, mockedTime, time.Time{})},
+				{b, e, b, e, e, b},
+				{E, E, E, E, e, e},
+				{E, E, E, E, b, e},
+				{E, E, E, E, e, b},
+			}, mockedTime, time.Time{}, withMetrics(domain.BoardMetrics{ThreeBV: 8, Openings: 1, Islands: 2}), withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2), domain.NewPosition(1, 3),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1), domain.NewPosition(2, 2), domain.NewPosition(2, 3),
+				domain.NewPosition(3, 0), domain.NewPosition(3, 1), domain.NewPosition(3, 2), domain.NewPosition(3, 3),
+			}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 5, domain.Board{
 					{e, e, e, e, e, e},
@@ -401,41 +749,173 @@ func TestService_RevealCell(t *testing.T) {
 					{e, e, e, e, e, e},
 				}, mockedTime, time.Time{})
 				dep.clock.EXPECT().Now().Return(mockedTime)
-				dep.rnd.EXPECT().GenerateN(game.Settings.Rows * game.Settings.Columns).Return([]int{2, 7, 16, 14, 23, 0})
+				dep.rnd.EXPECT().GenerateN(game.Settings.Rows * game.Settings.Columns).Return([]int{2, 7, 16, 14, 23, 0, 5, 12, 19})
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
 				dep.repository.EXPECT().Save(want.result).Return(nil)
 			},
 		},
 		{
-			name: "reveal cell in cascade successfully",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
-			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
-				{E, E, E, E, E, E},
-				{E, E, E, E, E, E},
-				{E, E, E, b, E, E},
-				{E, E, E, e, e, b},
-			}, mockedTime, time.Time{})},
+			name: "reveal first cell of a retried game keeps its bombs",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(0, 0)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateWon, 1, domain.Board{
+				{E, E, E},
+				{E, E, E},
+				{E, E, b},
+			}, mockedTime, time.Time{}, withSourceGame("abc"), withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(0, 0), domain.NewPosition(0, 1), domain.NewPosition(0, 2),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1),
+			}},
 			mock: func(dep dep, args args, want want) {
-				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
-					{e, e, e, e, e, e},
-					{e, e, e, e, e, e},
-					{e, e, e, b, e, e},
-					{e, e, e, e, e, b},
-				}, mockedTime, time.Time{})
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 1, domain.Board{
+					{e, e, e},
+					{e, e, e},
+					{e, e, b},
+				}, mockedTime, time.Time{}, withSourceGame("abc"))
+				dep.clock.EXPECT().Now().Return(mockedTime).Times(2)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal first cell of a puzzle game keeps its bombs",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(0, 0)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateWon, 1, domain.Board{
+				{E, E, E},
+				{E, E, E},
+				{E, E, b},
+			}, mockedTime, time.Time{}, withPuzzle("abc"), withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(0, 0), domain.NewPosition(0, 1), domain.NewPosition(0, 2),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1),
+			}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 1, domain.Board{
+					{e, e, e},
+					{e, e, e},
+					{e, e, b},
+				}, mockedTime, time.Time{}, withPuzzle("abc"))
+				dep.clock.EXPECT().Now().Return(mockedTime).Times(2)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal first cell successfully - only the cell is protected",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 2)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 5, domain.Board{
+				{b, e, b, e, e, e},
+				{e, b, e, e, e, e},
+				{e, e, E, e, b, e},
+				{e, e, e, e, e, b},
+			}, mockedTime, time.Time{}, withFirstClick(domain.FirstClickCell), withMetrics(domain.BoardMetrics{ThreeBV: 7, Openings: 2, Islands: 2}), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(2, 2)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 5, domain.NewEmptyBoard(4, 6), mockedTime, time.Time{}, withFirstClick(domain.FirstClickCell))
 				dep.clock.EXPECT().Now().Return(mockedTime)
+				dep.rnd.EXPECT().GenerateN(game.Settings.Rows * game.Settings.Columns).Return([]int{2, 7, 16, 14, 23, 0})
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
 				dep.repository.EXPECT().Save(want.result).Return(nil)
 			},
 		},
 		{
-			name: "reveal cell with bomb and lost game",
-			args: args{userID: "111", gameID: "xyz", row: 2, column: 3},
+			name: "reveal first cell with bomb and lost game - no protection",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 2)},
 			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateLost, 2, domain.Board{
 				{e, e, e, e, e, e},
 				{e, e, e, e, e, e},
-				{e, e, e, B, e, e},
+				{e, e, B, e, e, e},
 				{e, e, e, e, e, b},
-			}, mockedTime, mockedTime)},
+			}, mockedTime, mockedTime, withFirstClick(domain.FirstClickNone), withMetrics(domain.BoardMetrics{ThreeBV: 4, Openings: 1, Islands: 1}), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(2, 2)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 2, domain.NewEmptyBoard(4, 6), mockedTime, time.Time{}, withFirstClick(domain.FirstClickNone))
+				dep.clock.EXPECT().Now().Return(mockedTime).Times(2)
+				dep.rnd.EXPECT().GenerateN(game.Settings.Rows * game.Settings.Columns).Return([]int{14, 23, 0})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal first cell of a no guess game successfully",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(0, 0)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateWon, 1, domain.Board{
+				{E, E, E},
+				{E, E, E},
+				{E, E, b},
+			}, time.Time{}, time.Time{},
+				withNoGuess(),
+				withFirstClick(domain.FirstClickCell),
+				withMetrics(domain.BoardMetrics{ThreeBV: 1, Openings: 1}),
+				withMoves(1),
+				withPerformance(domain.Performance{Efficiency: 1}), withVersion(1),
+			), changed: []domain.Position{
+				domain.NewPosition(0, 0), domain.NewPosition(0, 1), domain.NewPosition(0, 2),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1),
+			}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 1, domain.NewEmptyBoard(3, 3), time.Time{}, time.Time{}, withNoGuess(), withFirstClick(domain.FirstClickCell))
+				dep.clock.EXPECT().Now().Return(mockedTime).AnyTimes()
+				gomock.InOrder(
+					dep.rnd.EXPECT().GenerateN(9).Return([]int{4, 0, 1, 2, 3, 5, 6, 7, 8}),
+					dep.rnd.EXPECT().GenerateN(9).Return([]int{0, 8, 1, 2, 3, 4, 5, 6, 7}),
+				)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal first cell of a no guess game when the time budget runs out",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(0, 0)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+				{E, e, e},
+				{e, b, e},
+				{e, e, e},
+			}, time.Time{}, time.Time{}, withNoGuess(), withFirstClick(domain.FirstClickCell), withMetrics(domain.BoardMetrics{ThreeBV: 8, Islands: 1}), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(0, 0)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 1, domain.NewEmptyBoard(3, 3), time.Time{}, time.Time{}, withNoGuess(), withFirstClick(domain.FirstClickCell))
+				gomock.InOrder(
+					dep.clock.EXPECT().Now().Return(mockedTime).Times(2),
+					dep.clock.EXPECT().Now().Return(mockedTime.Add(time.Minute)).AnyTimes(),
+				)
+				dep.rnd.EXPECT().GenerateN(9).Return([]int{4, 0, 1, 2, 3, 5, 6, 7, 8})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+
+		{
+			name: "reveal first cell of a no guess game when the time budget of the game runs out",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(0, 0)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+				{E, e, e},
+				{e, b, e},
+				{e, e, e},
+			}, time.Time{}, time.Time{}, withNoGuess(), withNoGuessBudget(10), withFirstClick(domain.FirstClickCell), withMetrics(domain.BoardMetrics{ThreeBV: 8, Islands: 1}), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(0, 0)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 1, domain.NewEmptyBoard(3, 3), time.Time{}, time.Time{}, withNoGuess(), withNoGuessBudget(10), withFirstClick(domain.FirstClickCell))
+				gomock.InOrder(
+					dep.clock.EXPECT().Now().Return(mockedTime).Times(2),
+					dep.clock.EXPECT().Now().Return(mockedTime.Add(10*time.Millisecond)).AnyTimes(),
+				)
+				dep.rnd.EXPECT().GenerateN(9).Return([]int{4, 0, 1, 2, 3, 5, 6, 7, 8})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell in cascade successfully",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
+				{E, E, E, E, E, E},
+				{E, E, E, E, E, E},
+				{E, E, E, b, E, E},
+				{E, E, E, e, e, b},
+			}, mockedTime, time.Time{}, withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(0, 0), domain.NewPosition(0, 1), domain.NewPosition(0, 2), domain.NewPosition(0, 3), domain.NewPosition(0, 4), domain.NewPosition(0, 5),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2), domain.NewPosition(1, 3), domain.NewPosition(1, 4), domain.NewPosition(1, 5),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1), domain.NewPosition(2, 2), domain.NewPosition(2, 4), domain.NewPosition(2, 5),
+				domain.NewPosition(3, 0), domain.NewPosition(3, 1), domain.NewPosition(3, 2),
+			}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
 					{e, e, e, e, e, e},
@@ -449,46 +929,577 @@ func TestService_RevealCell(t *testing.T) {
 			},
 		},
 		{
-			name: "reveal cell and won game",
-			args: args{userID: "111", gameID: "xyz", row: 1, column: 1},
+			name: "reveal cell in cascade around the edges of a torus and win game",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
 			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateWon, 2, domain.Board{
 				{E, E, E, E, E, E},
 				{E, E, E, E, E, E},
 				{E, E, E, b, E, E},
 				{E, E, E, E, E, b},
-			}, mockedTime, mockedTime)},
+			}, mockedTime, time.Time{}, withTopology(domain.TopologyTorus), withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(0, 0), domain.NewPosition(0, 1), domain.NewPosition(0, 2), domain.NewPosition(0, 3), domain.NewPosition(0, 4), domain.NewPosition(0, 5),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2), domain.NewPosition(1, 3), domain.NewPosition(1, 4), domain.NewPosition(1, 5),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1), domain.NewPosition(2, 2), domain.NewPosition(2, 4), domain.NewPosition(2, 5),
+				domain.NewPosition(3, 0), domain.NewPosition(3, 1), domain.NewPosition(3, 2), domain.NewPosition(3, 3), domain.NewPosition(3, 4),
+			}},
 			mock: func(dep dep, args args, want want) {
 				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
 					{e, e, e, e, e, e},
 					{e, e, e, e, e, e},
 					{e, e, e, b, e, e},
-					{e, e, e, E, E, b},
-				}, mockedTime, time.Time{})
+					{e, e, e, e, e, b},
+				}, mockedTime, time.Time{}, withTopology(domain.TopologyTorus))
 				dep.clock.EXPECT().Now().Return(mockedTime)
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
 				dep.repository.EXPECT().Save(want.result).Return(nil)
 			},
 		},
 		{
-			name: "fail at save in repository",
-			args: args{gameID: "xyz", row: 1, column: 1},
-			want: want{result: domain.Game{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			name: "reveal first cell of a game with disabled cells never places bombs on them",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 2)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+				{D, b, e},
+				{E, E, E},
+				{E, E, E},
+			}, mockedTime, time.Time{}, withFirstClick(domain.FirstClickCell), withMetrics(domain.BoardMetrics{ThreeBV: 2, Openings: 1, Islands: 1}), withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1), domain.NewPosition(2, 2),
+			}},
 			mock: func(dep dep, args args, want want) {
-				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
-					{e, e, e, e, e, e},
-					{e, e, e, e, e, e},
-					{e, e, e, b, e, e},
-					{e, e, e, E, E, b},
-				}, mockedTime, time.Time{})
-				gameResult := MockGameWithBoard("111", "xyz", domain.GameStateWon, 2, domain.Board{
-					{E, E, E, E, E, E},
-					{E, E, E, E, E, E},
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 1, domain.Board{
+					{D, e, e},
+					{e, e, e},
+					{e, e, e},
+				}, mockedTime, time.Time{}, withFirstClick(domain.FirstClickCell))
+				dep.clock.EXPECT().Now().Return(mockedTime)
+				dep.rnd.EXPECT().GenerateN(9).Return([]int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell in cascade and win game with disabled cells",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(0, 1)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateWon, 1, domain.Board{
+				{D, E, E, D},
+				{E, E, E, E},
+				{D, E, b, D},
+			}, mockedTime, time.Time{}, withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(0, 1), domain.NewPosition(0, 2),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2), domain.NewPosition(1, 3),
+				domain.NewPosition(2, 1),
+			}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+					{D, e, e, D},
+					{e, e, e, e},
+					{D, e, b, D},
+				}, mockedTime, time.Time{})
+				dep.clock.EXPECT().Now().Return(mockedTime)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "disabled cell cannot be revealed",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(0, 0)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, domain.Board{
+				{D, e, e, D},
+				{e, e, e, e},
+				{D, e, b, D},
+			}, mockedTime, time.Time{})},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&want.result, nil)
+			},
+		},
+		{
+			name: "reveal cell marked with a question in cascade successfully",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
+				{E, E, E, E, E, E},
+				{E, E, E, E, E, E},
+				{E, E, E, b, E, E},
+				{E, E, E, e, Q, b},
+			}, mockedTime, time.Time{}, withMoves(1), withVersion(1)), changed: []domain.Position{
+				domain.NewPosition(0, 0), domain.NewPosition(0, 1), domain.NewPosition(0, 2), domain.NewPosition(0, 3), domain.NewPosition(0, 4), domain.NewPosition(0, 5),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2), domain.NewPosition(1, 3), domain.NewPosition(1, 4), domain.NewPosition(1, 5),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1), domain.NewPosition(2, 2), domain.NewPosition(2, 4), domain.NewPosition(2, 5),
+				domain.NewPosition(3, 0), domain.NewPosition(3, 1), domain.NewPosition(3, 2),
+			}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
+					{e, e, e, e, e, e},
+					{e, Q, e, e, Q, e},
+					{e, e, e, b, e, e},
+					{e, e, e, e, Q, b},
+				}, mockedTime, time.Time{})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell with bomb marked with a question and lost game",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 3)},
+			want: want{result: MockGameWithCell("111", "xyz", domain.GameStateLost, 2, 3, domain.BombCellRevealed, withLives(0, 0), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(2, 3)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCoveredAndQuestioned, withLives(0, 1))
+				dep.clock.EXPECT().Now().Return(time.Time{})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell of a practice game remembers the previous state",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 3)},
+			want: want{result: MockGameWithCell("111", "xyz", domain.GameStateLost, 2, 3, domain.BombCellRevealed,
+				withLives(0, 0),
+				withPractice(),
+				withHistory(MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCovered, withLives(0, 1), withPractice())),
+				withMoves(1), withVersion(1),
+			), changed: []domain.Position{domain.NewPosition(2, 3)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCovered, withLives(0, 1), withPractice())
+				dep.clock.EXPECT().Now().Return(time.Time{})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell with bomb and lost game",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 3)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateLost, 2, domain.Board{
+				{e, e, e, e, e, e},
+				{e, e, e, e, e, e},
+				{e, e, e, B, e, e},
+				{e, e, e, e, e, b},
+			}, mockedTime, mockedTime, withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(2, 3)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
+					{e, e, e, e, e, e},
+					{e, e, e, e, e, e},
+					{e, e, e, b, e, e},
+					{e, e, e, e, e, b},
+				}, mockedTime, time.Time{})
+				dep.clock.EXPECT().Now().Return(mockedTime)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell with bomb and lose a life",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 3)},
+			want: want{result: MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellRevealed, withLives(3, 2), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(2, 3)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCovered, withLives(3, 3))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell of a hexagonal game given by its axial coordinates",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewAxialCoordinates(2, 2)},
+			want: want{result: MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellRevealed, withLives(3, 2), withMoves(1), withTopology(domain.TopologyHex), withVersion(1)), changed: []domain.Position{domain.NewPosition(2, 3)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCovered, withLives(3, 3), withTopology(domain.TopologyHex))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "axial coordinates cannot be used in a game that is not hexagonal",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewAxialCoordinates(2, 2)},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "axial coordinates can only be used in hexagonal boards", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCovered)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "reveal cell with bomb and lost game with no lives remaining",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(2, 3)},
+			want: want{result: MockGameWithCell("111", "xyz", domain.GameStateLost, 2, 3, domain.BombCellRevealed, withLives(3, 0), withMoves(1), withVersion(1)), changed: []domain.Position{domain.NewPosition(2, 3)}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCovered, withLives(3, 1))
+				dep.clock.EXPECT().Now().Return(mockedTime)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "reveal cell and won game",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: MockGameWithBoard("111", "xyz", domain.GameStateWon, 2, domain.Board{
+				{E, E, E, E, E, E},
+				{E, E, E, E, E, E},
+				{E, E, E, b, E, E},
+				{E, E, E, E, E, b},
+			}, mockedTime, mockedTime,
+				withTimes(mockedStartedAt, mockedStartedAt.Add(30*time.Second)),
+				withMetrics(domain.BoardMetrics{ThreeBV: 3, Openings: 1, Islands: 1}),
+				withMoves(4),
+				withPerformance(domain.Performance{Efficiency: 0.75, ThreeBVPerSecond: 0.1}), withVersion(1),
+			), changed: []domain.Position{
+				domain.NewPosition(0, 0), domain.NewPosition(0, 1), domain.NewPosition(0, 2), domain.NewPosition(0, 3), domain.NewPosition(0, 4), domain.NewPosition(0, 5),
+				domain.NewPosition(1, 0), domain.NewPosition(1, 1), domain.NewPosition(1, 2), domain.NewPosition(1, 3), domain.NewPosition(1, 4), domain.NewPosition(1, 5),
+				domain.NewPosition(2, 0), domain.NewPosition(2, 1), domain.NewPosition(2, 2), domain.NewPosition(2, 4), domain.NewPosition(2, 5),
+				domain.NewPosition(3, 0), domain.NewPosition(3, 1), domain.NewPosition(3, 2),
+			}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
+					{e, e, e, e, e, e},
+					{e, e, e, e, e, e},
+					{e, e, e, b, e, e},
+					{e, e, e, E, E, b},
+				}, mockedTime, time.Time{}, withTimes(mockedStartedAt, time.Time{}), withMetrics(domain.BoardMetrics{ThreeBV: 3, Openings: 1, Islands: 1}), withMoves(3))
+				dep.clock.EXPECT().Now().Return(mockedStartedAt.Add(30 * time.Second))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "fail at save in repository",
+			args: args{userID: "111", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 2, domain.Board{
+					{e, e, e, e, e, e},
+					{e, e, e, e, e, e},
+					{e, e, e, b, e, e},
+					{e, e, e, E, E, b},
+				}, mockedTime, time.Time{})
+				gameResult := MockGameWithBoard("111", "xyz", domain.GameStateWon, 2, domain.Board{
+					{E, E, E, E, E, E},
+					{E, E, E, E, E, E},
 					{E, E, E, b, E, E},
 					{E, E, E, E, E, b},
 				}, mockedTime, mockedTime)
 				dep.clock.EXPECT().Now().Return(mockedTime)
 				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
-				dep.repository.EXPECT().Save(gameResult).Return(apperrors.Internal)
+				dep.repository.EXPECT().Save(apply(gameResult, withMoves(1), withVersion(1))).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, changed, err := service.RevealCell(tt.args.userID, tt.args.gameID, tt.args.coordinates)
+
+			assert.Equal(t, tt.want.result, result)
+			assert.ElementsMatch(t, tt.want.changed, changed)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Hint(t *testing.T) {
+	type args struct {
+		userID string
+		gameID string
+	}
+	type want struct {
+		result domain.Game
+		hint   domain.Hint
+		err    error
+	}
+
+	ongoingBoard := domain.Board{
+		{e, E, E},
+		{b, E, E},
+		{e, E, E},
+	}
+
+	retriedBoard := domain.Board{
+		{e, e, e},
+		{e, b, e},
+		{b, e, e},
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "hint for a new game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{
+				result: MockGame("111", "xyz", domain.GameStateNew, withHints(1), withVersion(1)),
+				hint:   domain.Hint{Position: domain.NewPosition(3, 3), Kind: domain.HintSafe, Reason: "the first revealed cell never has a bomb"},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateNew)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "hint for a retried game with a bomb in the center",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{
+				result: MockGameWithBoard("111", "xyz", domain.GameStateNew, 2, retriedBoard, time.Time{}, time.Time{}, withSourceGame("abc"), withHints(1), withVersion(1)),
+				hint: domain.Hint{
+					Position:    domain.NewPosition(1, 1),
+					Kind:        domain.HintGuess,
+					Reason:      "the bombs have already been placed, so the first click is not protected",
+					Probability: 2.0 / 9,
+				},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateNew, 2, retriedBoard, time.Time{}, time.Time{}, withSourceGame("abc"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "hint for an ongoing game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{
+				result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, ongoingBoard, mockedStartedAt, time.Time{}, withHints(3), withVersion(1)),
+				hint:   domain.Hint{Position: domain.NewPosition(2, 0), Kind: domain.HintSafe, Reason: "the bombs around (1, 1) are all shared with (0, 1), so its other neighbors are safe"},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, ongoingBoard, mockedStartedAt, time.Time{}, withHints(2))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.clock.EXPECT().Now().Return(mockedStartedAt).AnyTimes()
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "hint for an ongoing game when the time to prove a cell safe runs out",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{
+				result: MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, ongoingBoard, mockedStartedAt, time.Time{}, withHints(3), withVersion(1)),
+				hint: domain.Hint{
+					Position:    domain.NewPosition(0, 0),
+					Kind:        domain.HintGuess,
+					Reason:      "there was no time to prove a cell safe, (0, 0) has the lowest estimated probability of having a bomb",
+					Probability: 0.5,
+				},
+			},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, ongoingBoard, mockedStartedAt, time.Time{}, withHints(2))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				gomock.InOrder(
+					dep.clock.EXPECT().Now().Return(mockedStartedAt),
+					dep.clock.EXPECT().Now().Return(mockedStartedAt.Add(time.Minute)).AnyTimes(),
+				)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "game has already been finished",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "game has already finished", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateWon)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "hint for a match game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.InvalidInput, nil, "match games cannot get hints", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, ongoingBoard, mockedStartedAt, time.Time{}, withMatch("m1"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "game not found",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: domain.Game{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateNew)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, hint, err := service.Hint(tt.args.userID, tt.args.gameID)
+
+			assert.Equal(t, tt.want.result, result)
+			assert.Equal(t, tt.want.hint, hint)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Analyze(t *testing.T) {
+	type args struct {
+		userID string
+		gameID string
+	}
+	type want struct {
+		result domain.Analysis
+		err    error
+	}
+
+	board := domain.Board{
+		{E, b, e},
+	}
+
+	analysis := domain.Analysis{
+		Method: domain.AnalysisMethodExact,
+		Cells: []domain.CellProbability{
+			{Position: domain.NewPosition(0, 1), Probability: 1},
+			{Position: domain.NewPosition(0, 2), Probability: 0},
+		},
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "analysis of a finished game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: analysis},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateWon, 1, board, mockedStartedAt, mockedStartedAt)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.clock.EXPECT().Now().Return(mockedStartedAt).AnyTimes()
+			},
+		},
+		{
+			name: "analysis of an ongoing practice game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: analysis},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, board, mockedStartedAt, time.Time{}, withPractice())
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.clock.EXPECT().Now().Return(mockedStartedAt).AnyTimes()
+			},
+		},
+		{
+			name: "game is ongoing and not a practice game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "analysis is only available for finished or practice games", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateOnGoing, 1, board, mockedStartedAt, time.Time{})
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "game not found",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Analyze(tt.args.userID, tt.args.gameID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Undo(t *testing.T) {
+	type args struct {
+		userID string
+		gameID string
+	}
+	type want struct {
+		result domain.Game
+		err    error
+	}
+
+	ongoing := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 2, 3, domain.BombCellCovered, withLives(0, 1), withPractice(), withMoves(2), withHints(1))
+	lost := MockGameWithCell("111", "xyz", domain.GameStateLost, 2, 3, domain.BombCellRevealed, withLives(0, 0), withPractice(), withMoves(3), withHints(2))
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "undo a lost game restoring its moves and hints used",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: apply(ongoing, withUndos(1), withVersion(1))},
+			mock: func(dep dep, args args, want want) {
+				game := apply(lost, withHistory(ongoing))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "game is not a practice game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "undo is only available for practice games", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "there is no move to undo",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "there is no move to undo", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateNew, withPractice())
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "game not found",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				game := apply(lost, withHistory(ongoing))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
 			},
 		},
 	}
@@ -498,9 +1509,97 @@ func TestService_RevealCell(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			dep := newDep(t)
-			service := game.NewService(dep.rnd, dep.clock, dep.repository)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
 			tt.mock(dep, tt.args, tt.want)
-			result, err := service.RevealCell(tt.args.userID, tt.args.gameID, tt.args.row, tt.args.column)
+			result, err := service.Undo(tt.args.userID, tt.args.gameID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Retry(t *testing.T) {
+	type args struct {
+		userID string
+		gameID string
+	}
+	type want struct {
+		result domain.Game
+		err    error
+	}
+
+	lostBoard := domain.Board{
+		{E, E, E},
+		{E, X, Y},
+		{e, e, B},
+	}
+
+	retried := MockGameWithBoard("111", "abc", domain.GameStateNew, 2, domain.Board{
+		{e, e, e},
+		{e, e, b},
+		{e, e, b},
+	}, time.Time{}, time.Time{}, withSourceGame("xyz"), withFirstClick(domain.FirstClickNone), withMetrics(domain.BoardMetrics{ThreeBV: 3, Openings: 1}), withVersion(1))
+	retried.RemainingLives = 1
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "retry a lost game",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{result: retried},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateLost, 2, lostBoard, mockedStartedAt, mockedStartedAt, withMetrics(domain.BoardMetrics{ThreeBV: 3, Openings: 1}))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.rnd.EXPECT().GenerateID().Return("abc")
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "game has not finished yet",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "game has not finished yet", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "game not found",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGameWithBoard("111", "xyz", domain.GameStateLost, 2, lostBoard, mockedStartedAt, mockedStartedAt)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.rnd.EXPECT().GenerateID().Return("abc")
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Retry(tt.args.userID, tt.args.gameID)
 
 			assert.Equal(t, tt.want.result, result)
 			if err != nil && tt.want.err != nil {
@@ -513,35 +1612,182 @@ func TestService_RevealCell(t *testing.T) {
 
 // ··· Mocking game primitives ··· //
 
-func MockGame(userID string, gameID string, state string) domain.Game {
+var mockedStartedAt = time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func MockTimedGame(userID string, gameID string, state string, endedAt time.Time, options ...gameOption) domain.Game {
+	game := MockGame(userID, gameID, state)
+	game.Settings.TimeLimit = 60
+	game.StartedAt = mockedStartedAt
+	game.EndedAt = endedAt
+
+	return apply(game, options...)
+}
+
+func MockGame(userID string, gameID string, state string, options ...gameOption) domain.Game {
 	game := domain.Game{
-		ID:       gameID,
-		UserID:   userID,
-		Board:    domain.NewEmptyBoard(6, 6),
-		Settings: domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10},
-		State:    state,
+		ID:             gameID,
+		UserID:         userID,
+		Board:          domain.NewEmptyBoard(6, 6),
+		Settings:       domain.GameSettings{Rows: 6, Columns: 6, BombsNumber: 10},
+		State:          state,
+		RemainingLives: 1,
 	}
 
 	if state == "" {
 		game.State = domain.GameStateNew
 	}
 
-	return game
+	return apply(game, options...)
 }
 
-func MockGameWithCell(userID, gameID string, state string, row int, column int, cell domain.Cell) domain.Game {
+func MockGameWithCell(userID, gameID string, state string, row int, column int, cell domain.Cell, options ...gameOption) domain.Game {
 	game := MockGame(userID, gameID, state)
 	game.Board.Set(domain.NewPosition(row, column), cell)
 
-	return game
+	return apply(game, options...)
 }
 
-func MockGameWithBoard(userID, gameID string, state string, bombsNumber int, board domain.Board, startedAt time.Time, endedAt time.Time) domain.Game {
-	return domain.Game{
+func MockGameWithBoard(userID, gameID string, state string, bombsNumber int, board domain.Board, startedAt time.Time, endedAt time.Time, options ...gameOption) domain.Game {
+	return apply(domain.Game{
 		ID:       gameID,
 		UserID:   userID,
 		Board:    board,
 		Settings: domain.GameSettings{Rows: len(board), Columns: len(board[0]), BombsNumber: bombsNumber},
 		State:    state,
+	}, options...)
+}
+
+// gameOption sets a field of a mocked game, so every test case only states how its game differs from the base one
+type gameOption func(game *domain.Game)
+
+func apply(game domain.Game, options ...gameOption) domain.Game {
+	for _, option := range options {
+		option(&game)
+	}
+
+	return game
+}
+
+func withLives(lives int, remainingLives int) gameOption {
+	return func(game *domain.Game) {
+		game.Settings.Lives = lives
+		game.RemainingLives = remainingLives
+	}
+}
+
+func withHints(hintsUsed int) gameOption {
+	return func(game *domain.Game) {
+		game.HintsUsed = hintsUsed
+	}
+}
+
+func withMoves(moves int) gameOption {
+	return func(game *domain.Game) {
+		game.Moves = moves
+	}
+}
+
+func withMetrics(metrics domain.BoardMetrics) gameOption {
+	return func(game *domain.Game) {
+		game.Metrics = metrics
+	}
+}
+
+func withPerformance(performance domain.Performance) gameOption {
+	return func(game *domain.Game) {
+		game.Performance = performance
+	}
+}
+
+func withTimes(startedAt time.Time, endedAt time.Time) gameOption {
+	return func(game *domain.Game) {
+		game.StartedAt = startedAt
+		game.EndedAt = endedAt
+	}
+}
+
+// withHistory sets the history the game has after a move made from the given previous state
+func withHistory(previous domain.Game) gameOption {
+	return func(game *domain.Game) {
+		previous.Remember(10)
+		game.History = previous.History
+	}
+}
+
+func withUndos(undos int) gameOption {
+	return func(game *domain.Game) {
+		game.Undos = undos
+	}
+}
+
+func withSourceGame(sourceGameID string) gameOption {
+	return func(game *domain.Game) {
+		game.SourceGameID = sourceGameID
+	}
+}
+
+func withMatch(matchID string) gameOption {
+	return func(game *domain.Game) {
+		game.MatchID = matchID
+	}
+}
+
+func withParticipants(participants ...string) gameOption {
+	return func(game *domain.Game) {
+		game.Participants = participants
+	}
+}
+
+func withVersion(version int) gameOption {
+	return func(game *domain.Game) {
+		game.Version = version
+	}
+}
+
+func withPuzzle(puzzleID string) gameOption {
+	return func(game *domain.Game) {
+		game.Settings.PuzzleID = puzzleID
+	}
+}
+
+func withFirstClick(firstClick string) gameOption {
+	return func(game *domain.Game) {
+		game.Settings.FirstClick = firstClick
+	}
+}
+
+func withNoGuess() gameOption {
+	return func(game *domain.Game) {
+		game.Settings.NoGuess = true
+	}
+}
+
+func withNoGuessBudget(budget int) gameOption {
+	return func(game *domain.Game) {
+		game.Settings.NoGuessBudget = budget
+	}
+}
+
+func withPractice() gameOption {
+	return func(game *domain.Game) {
+		game.Settings.Practice = true
+	}
+}
+
+func withQuestionMarks() gameOption {
+	return func(game *domain.Game) {
+		game.Settings.QuestionMarks = true
+	}
+}
+
+func withMask(mask domain.Mask) gameOption {
+	return func(game *domain.Game) {
+		game.Settings.Mask = mask
+	}
+}
+
+func withTopology(topology string) gameOption {
+	return func(game *domain.Game) {
+		game.Settings.Topology = topology
 	}
 }
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	maxMatchUpdateAttempts = 5
+)
+
+// CreateMatchGames creates a game for each of the users given, all of them linked to the match and played on the same
+// board. Since the players do not reveal the same first cell, the bombs are placed in advance and the first click is
+// never protected
+func (srv *service) CreateMatchGames(matchID string, userIDs []string, settings domain.GameSettings) ([]domain.Game, error) {
+	if len(userIDs) == 0 {
+		return nil, errors.New(apperrors.InvalidInput, nil, "a match needs at least one player", "")
+	}
+
+	if settings.Practice {
+		return nil, errors.New(apperrors.InvalidInput, nil, "match games cannot be practice games", "")
+	}
+
+	settings.NoGuess, settings.FirstClick = false, domain.FirstClickNone
+
+	template, err := srv.newGame(userIDs[0], settings)
+	if err != nil {
+		return nil, errors.Wrap(err, err.Error())
+	}
+
+	if template.Settings.PuzzleID == "" {
+		srv.fillBoardWithBombs(&template, nil)
+		template.Metrics = template.Board.Metrics(template.Topology())
+	}
+
+	games := make([]domain.Game, len(userIDs))
+	for i, userID := range userIDs {
+		game := template
+		game.UserID = userID
+		game.MatchID = matchID
+		game.Board = template.Board.Copy()
+
+		if i > 0 {
+			game.ID = srv.rnd.GenerateID()
+		}
+
+		if err := srv.save(&game); err != nil {
+			return nil, errors.Wrap(err, err.Error())
+		}
+
+		games[i] = game
+	}
+
+	return games, nil
+}
+
+// track records the given game in its match, if it belongs to one, and publishes the new state of the match to its
+// participants. The match is updated again when it was changed concurrently, so no player is missed when several of
+// them finish at once. The game has already been saved, so failing at updating the match is only logged
+func (srv *service) track(game domain.Game) {
+	if game.MatchID == "" {
+		return
+	}
+
+	for attempt := 0; attempt < maxMatchUpdateAttempts; attempt++ {
+		match, err := srv.matchRepository.Get(game.MatchID)
+		if err != nil {
+			log.Error(errors.String(errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting match from repository")))
+			return
+		}
+
+		if match == nil || !match.Update(game) {
+			return
+		}
+
+		match.Version++
+
+		err = srv.matchRepository.Save(*match)
+		if errors.Code(err) == errors.Code(apperrors.Conflict) {
+			continue
+		}
+
+		if err != nil {
+			log.Error(errors.String(errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving match into repository")))
+			return
+		}
+
+		srv.matchNotifier.Publish(*match)
+		return
+	}
+
+	log.Error(errors.String(errors.New(apperrors.Conflict, nil, "an internal error has occurred", "failed at updating match changed concurrently too many times")))
+}
@@ -0,0 +1,218 @@
+package game_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/service/game"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestService_CreateMatchGames(t *testing.T) {
+	type args struct {
+		matchID  string
+		userIDs  []string
+		settings domain.GameSettings
+	}
+	type want struct {
+		result []domain.Game
+		err    error
+	}
+
+	board := domain.Board{
+		{e, e},
+		{e, b},
+	}
+	first := MockGameWithBoard("111", "g1", domain.GameStateNew, 1, board, time.Time{}, time.Time{}, withFirstClick(domain.FirstClickNone), withMatch("m1"), withVersion(1))
+	first.RemainingLives = 1
+	first.Metrics = board.Metrics(domain.StandardTopology)
+	second := first
+	second.ID, second.UserID = "g2", "222"
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "create games on the same board successfully",
+			args: args{matchID: "m1", userIDs: []string{"111", "222"}, settings: domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1, NoGuess: true}},
+			want: want{result: []domain.Game{first, second}},
+			mock: func(dep dep, args args, want want) {
+				gomock.InOrder(
+					dep.rnd.EXPECT().GenerateID().Return("g1"),
+					dep.rnd.EXPECT().GenerateN(4).Return([]int{3, 0, 1, 2}),
+					dep.rnd.EXPECT().GenerateID().Return("g2"),
+				)
+				dep.repository.EXPECT().Save(want.result[0]).Return(nil)
+				dep.repository.EXPECT().Save(want.result[1]).Return(nil)
+			},
+		},
+		{
+			name: "practice games are not allowed",
+			args: args{matchID: "m1", userIDs: []string{"111", "222"}, settings: domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1, Practice: true}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "match games cannot be practice games", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid settings",
+			args: args{matchID: "m1", userIDs: []string{"111", "222"}, settings: domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 4}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "the number of bombs must be less than the number of cells", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{matchID: "m1", userIDs: []string{"111", "222"}, settings: domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1}},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return("g1")
+				dep.rnd.EXPECT().GenerateN(4).Return([]int{3, 0, 1, 2})
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.CreateMatchGames(tt.args.matchID, tt.args.userIDs, tt.args.settings)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Get_Match(t *testing.T) {
+	// Setup
+	dep := newDep(t)
+	service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+
+	current := MockTimedGame("111", "g1", domain.GameStateOnGoing, time.Time{}, withMatch("m1"))
+	expired := MockTimedGame("111", "g1", domain.GameStateTimeout, mockedStartedAt.Add(time.Minute), withMatch("m1"), withVersion(1))
+	match := domain.Match{ID: "m1", State: domain.MatchStateOnGoing, Participants: []domain.MatchParticipant{
+		{UserID: "111", GameID: "g1", State: domain.GameStateOnGoing},
+		{UserID: "222", GameID: "g2", State: domain.GameStateOnGoing},
+	}}
+
+	dep.clock.EXPECT().Now().Return(current.StartedAt.Add(2 * time.Minute))
+	dep.repository.EXPECT().Get("111", "g1").Return(&current, nil)
+	dep.repository.EXPECT().Save(expired).Return(nil)
+	dep.matches.EXPECT().Get("m1").Return(&match, nil)
+	dep.matches.EXPECT().Save(gomock.Any()).Return(nil)
+	dep.notifier.EXPECT().Publish(gomock.Any())
+
+	// Execute
+	result, err := service.Get("111", "g1")
+
+	// Verify
+	assert.Nil(t, err)
+	assert.Equal(t, expired, result)
+	assert.Equal(t, domain.MatchStateFinished, match.State)
+	assert.Equal(t, "222", match.WinnerID)
+	assert.Equal(t, domain.GameStateTimeout, match.Participants[0].State)
+}
+
+func TestService_RevealCell_Match(t *testing.T) {
+	mockedTime, _ := time.Parse(time.RFC3339, time.RFC3339)
+
+	newGame := func(state string, board domain.Board) domain.Game {
+		return MockGameWithBoard("111", "g1", state, 1, board, mockedTime, time.Time{}, withMatch("m1"))
+	}
+
+	ongoing := domain.Match{
+		ID:      "m1",
+		State:   domain.MatchStateOnGoing,
+		Version: 3,
+		Participants: []domain.MatchParticipant{
+			{UserID: "111", GameID: "g1", State: domain.GameStateNew},
+			{UserID: "222", GameID: "g2", State: domain.GameStateOnGoing, Moves: 4},
+		},
+	}
+
+	won := ongoing
+	won.State, won.WinnerID, won.Version = domain.MatchStateFinished, "111", 4
+	won.Participants = []domain.MatchParticipant{
+		{UserID: "111", GameID: "g1", State: domain.GameStateWon, Moves: 1, RevealedPercentage: 100},
+		{UserID: "222", GameID: "g2", State: domain.GameStateOnGoing, Moves: 4},
+	}
+
+	type want struct {
+		result domain.Game
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(dep, want)
+	}{
+		{
+			name: "winning the game finishes the match",
+			want: want{result: apply(newGame(domain.GameStateWon, domain.Board{{E, E, E}, {E, E, E}, {E, E, b}}), withMoves(1), withVersion(1))},
+			mock: func(dep dep, want want) {
+				match := ongoing
+				match.Participants = append([]domain.MatchParticipant{}, ongoing.Participants...)
+
+				dep.matches.EXPECT().Get("m1").Return(&match, nil)
+				dep.matches.EXPECT().Save(won).Return(nil)
+				dep.notifier.EXPECT().Publish(won)
+			},
+		},
+		{
+			name: "the match is read again when it has been changed concurrently",
+			want: want{result: apply(newGame(domain.GameStateWon, domain.Board{{E, E, E}, {E, E, E}, {E, E, b}}), withMoves(1), withVersion(1))},
+			mock: func(dep dep, want want) {
+				stale := ongoing
+				stale.Participants = append([]domain.MatchParticipant{}, ongoing.Participants...)
+				match := ongoing
+				match.Participants = append([]domain.MatchParticipant{}, ongoing.Participants...)
+
+				gomock.InOrder(
+					dep.matches.EXPECT().Get("m1").Return(&stale, nil),
+					dep.matches.EXPECT().Save(won).Return(errors.New(apperrors.Conflict, nil, "the match has been changed concurrently", "")),
+					dep.matches.EXPECT().Get("m1").Return(&match, nil),
+					dep.matches.EXPECT().Save(won).Return(nil),
+				)
+				dep.notifier.EXPECT().Publish(won)
+			},
+		},
+		{
+			name: "failing at updating the match keeps the game",
+			want: want{result: apply(newGame(domain.GameStateWon, domain.Board{{E, E, E}, {E, E, E}, {E, E, b}}), withMoves(1), withVersion(1))},
+			mock: func(dep dep, want want) {
+				dep.matches.EXPECT().Get("m1").Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+
+			current := newGame(domain.GameStateNew, domain.Board{{e, e, e}, {e, e, e}, {e, e, b}})
+			dep.clock.EXPECT().Now().Return(mockedTime).Times(2)
+			dep.repository.EXPECT().Get("111", "g1").Return(&current, nil)
+			dep.repository.EXPECT().Save(tt.want.result).Return(nil)
+			tt.mock(dep, tt.want)
+
+			result, _, err := service.RevealCell("111", "g1", domain.NewOffsetCoordinates(0, 0))
+
+			assert.Equal(t, tt.want.result, result)
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"fmt"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+)
+
+const (
+	maxParticipants   = 10
+	maxUpdateAttempts = 5
+	actionLogSize     = 100
+)
+
+// AddParticipant lets the user given play the game along with its owner. Only the owner can add participants
+func (srv *service) AddParticipant(userID string, gameID string, participantID string) (domain.Game, error) {
+	game, err := srv.update(userID, gameID, func(game *domain.Game) (bool, error) {
+		if game.UserID != userID {
+			return false, errors.New(apperrors.Forbidden, nil, "only the owner of the game can add participants", "")
+		}
+
+		if game.MatchID != "" {
+			return false, errors.New(apperrors.InvalidInput, nil, "match games cannot be shared", "")
+		}
+
+		if participantID == "" {
+			return false, errors.New(apperrors.InvalidInput, nil, "the participant has no user id", "")
+		}
+
+		if game.IsParticipant(participantID) {
+			return false, errors.New(apperrors.InvalidInput, nil, "the user already participates in the game", "")
+		}
+
+		if len(game.Participants) >= maxParticipants {
+			return false, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("a game cannot have more than %d participants", maxParticipants), "")
+		}
+
+		game.Participants = append(game.Participants, participantID)
+
+		return true, nil
+	})
+	if err != nil {
+		return domain.Game{}, errors.Wrap(err, err.Error())
+	}
+
+	return game, nil
+}
+
+// RemoveParticipant stops the user given from playing the game. The owner can remove any participant, while the rest
+// of the participants can only leave the game themselves
+func (srv *service) RemoveParticipant(userID string, gameID string, participantID string) (domain.Game, error) {
+	game, err := srv.update(userID, gameID, func(game *domain.Game) (bool, error) {
+		if game.UserID != userID && participantID != userID {
+			return false, errors.New(apperrors.Forbidden, nil, "only the owner of the game can remove other participants", "")
+		}
+
+		for i, participant := range game.Participants {
+			if participant == participantID {
+				game.Participants = append(game.Participants[:i:i], game.Participants[i+1:]...)
+				return true, nil
+			}
+		}
+
+		return false, errors.New(apperrors.NotFound, nil, "participant has not been found", "")
+	})
+	if err != nil {
+		return domain.Game{}, errors.Wrap(err, err.Error())
+	}
+
+	return game, nil
+}
+
+// update applies the given change to the game and saves it when the change reports that the game has changed.
+// Since the participants of a shared game may act at once, the change is applied again on the last saved game when
+// another participant saved it first, so their actions are applied one after the other
+func (srv *service) update(userID string, gameID string, change func(game *domain.Game) (bool, error)) (domain.Game, error) {
+	for attempt := 1; ; attempt++ {
+		game, err := srv.Get(userID, gameID)
+		if err != nil {
+			return domain.Game{}, errors.Wrap(err, err.Error())
+		}
+
+		changed, err := change(&game)
+		if err != nil {
+			return domain.Game{}, errors.Wrap(err, err.Error())
+		}
+
+		if !changed {
+			return game, nil
+		}
+
+		err = srv.save(&game)
+		if errors.Code(err) == errors.Code(apperrors.Conflict) && attempt < maxUpdateAttempts {
+			continue
+		}
+
+		if err != nil {
+			return domain.Game{}, errors.Wrap(err, err.Error())
+		}
+
+		srv.track(game)
+
+		return game, nil
+	}
+}
+
+// save saves the given game and publishes it to its spectators. Every game is versioned, so it is saved only if nobody
+// else has saved it since it was read: a participant, the owner from another client or the sweeper of expired games
+func (srv *service) save(game *domain.Game) error {
+	game.Version++
+
+	err := srv.repository.Save(*game)
+	if errors.Code(err) == errors.Code(apperrors.Conflict) {
+		return errors.New(apperrors.Conflict, err, "the game has been changed by another participant, try again", "failed at saving game changed concurrently")
+	}
+
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving game into repository")
+	}
+
+	srv.gameNotifier.Publish(*game)
+
+	return nil
+}
+
+// record keeps who made the given action on a shared game
+func record(game *domain.Game, userID string, action domain.Action) {
+	if game.IsShared() {
+		game.Record(userID, action, actionLogSize)
+	}
+}
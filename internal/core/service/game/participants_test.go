@@ -0,0 +1,299 @@
+package game_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/service/game"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestService_AddParticipant(t *testing.T) {
+	type args struct {
+		userID        string
+		gameID        string
+		participantID string
+	}
+	type want struct {
+		result domain.Game
+		err    error
+	}
+
+	conflict := errors.New(apperrors.Conflict, nil, "the game has been changed concurrently", "")
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "add participant successfully",
+			args: args{userID: "111", gameID: "xyz", participantID: "222"},
+			want: want{result: MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"), withVersion(1))},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "add participant again when the game has been changed concurrently",
+			args: args{userID: "111", gameID: "xyz", participantID: "333"},
+			want: want{result: MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222", "333"), withVersion(2))},
+			mock: func(dep dep, args args, want want) {
+				stale := MockGame("111", "xyz", domain.GameStateOnGoing)
+				current := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"), withVersion(1))
+				gomock.InOrder(
+					dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&stale, nil),
+					dep.repository.EXPECT().Save(gomock.Any()).Return(conflict),
+					dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&current, nil),
+					dep.repository.EXPECT().Save(want.result).Return(nil),
+				)
+			},
+		},
+		{
+			name: "only the owner can add participants",
+			args: args{userID: "222", gameID: "xyz", participantID: "333"},
+			want: want{err: errors.New(apperrors.Forbidden, nil, "only the owner of the game can add participants", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "match games cannot be shared",
+			args: args{userID: "111", gameID: "xyz", participantID: "222"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "match games cannot be shared", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withMatch("m1"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "participant without user id",
+			args: args{userID: "111", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "the participant has no user id", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "user already participates in the game",
+			args: args{userID: "111", gameID: "xyz", participantID: "111"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "the user already participates in the game", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "too many participants",
+			args: args{userID: "111", gameID: "xyz", participantID: "999"},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "a game cannot have more than 10 participants", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("a", "b", "c", "d", "e", "f", "g", "h", "i", "j"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{userID: "111", gameID: "xyz", participantID: "222"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving game into repository")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing)
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.AddParticipant(tt.args.userID, tt.args.gameID, tt.args.participantID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_RemoveParticipant(t *testing.T) {
+	type args struct {
+		userID        string
+		gameID        string
+		participantID string
+	}
+	type want struct {
+		result domain.Game
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "owner removes a participant successfully",
+			args: args{userID: "111", gameID: "xyz", participantID: "222"},
+			want: want{result: MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("333"), withVersion(3))},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222", "333"), withVersion(2))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "participant leaves the game, which keeps being versioned",
+			args: args{userID: "222", gameID: "xyz", participantID: "222"},
+			want: want{result: MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants([]string{}...), withVersion(3))},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"), withVersion(2))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "only the owner can remove other participants",
+			args: args{userID: "222", gameID: "xyz", participantID: "333"},
+			want: want{err: errors.New(apperrors.Forbidden, nil, "only the owner of the game can remove other participants", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222", "333"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+		{
+			name: "participant not found",
+			args: args{userID: "111", gameID: "xyz", participantID: "444"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "participant has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.RemoveParticipant(tt.args.userID, tt.args.gameID, tt.args.participantID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_MarkCell_Shared(t *testing.T) {
+	type args struct {
+		userID      string
+		gameID      string
+		coordinates domain.Coordinates
+	}
+	type want struct {
+		result  domain.Game
+		changed []domain.Position
+		err     error
+	}
+
+	conflict := errors.New(apperrors.Conflict, nil, "the game has been changed concurrently", "")
+	marked := func(version int) domain.Game {
+		game := MockGameWithCell("111", "xyz", domain.GameStateOnGoing, 1, 1, domain.EmptyCellCoveredAndMarked, withParticipants("222"), withVersion(version))
+		game.Moves = 1
+		game.Log = []domain.ActionRecord{{UserID: "222", Action: domain.Action{Type: domain.ActionMark, Coordinates: domain.NewOffsetCoordinates(1, 1)}, Move: 1}}
+
+		return game
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "participant marks a cell and the action is recorded",
+			args: args{userID: "222", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: marked(4), changed: []domain.Position{{Row: 1, Column: 1}}},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"), withVersion(3))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "the action is applied again on the game saved by another participant",
+			args: args{userID: "222", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{result: marked(5), changed: []domain.Position{{Row: 1, Column: 1}}},
+			mock: func(dep dep, args args, want want) {
+				stale := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"), withVersion(3))
+				current := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"), withVersion(4))
+				gomock.InOrder(
+					dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&stale, nil),
+					dep.repository.EXPECT().Save(gomock.Any()).Return(conflict),
+					dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&current, nil),
+					dep.repository.EXPECT().Save(want.result).Return(nil),
+				)
+			},
+		},
+		{
+			name: "give up when the game keeps being changed concurrently",
+			args: args{userID: "222", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{err: errors.New(apperrors.Conflict, nil, "the game has been changed by another participant, try again", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.userID, args.gameID).DoAndReturn(func(userID string, gameID string) (*domain.Game, error) {
+					game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"), withVersion(3))
+					return &game, nil
+				}).Times(5)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(conflict).Times(5)
+			},
+		},
+		{
+			name: "users that do not participate cannot act",
+			args: args{userID: "333", gameID: "xyz", coordinates: domain.NewOffsetCoordinates(1, 1)},
+			want: want{err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				game := MockGame("111", "xyz", domain.GameStateOnGoing, withParticipants("222"))
+				dep.repository.EXPECT().Get(args.userID, args.gameID).Return(&game, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := game.NewService(dep.rnd, dep.clock, dep.repository, dep.puzzles, dep.matches, dep.notifier, dep.games)
+			tt.mock(dep, tt.args, tt.want)
+			result, changed, err := service.MarkCell(tt.args.userID, tt.args.gameID, tt.args.coordinates)
+
+			assert.Equal(t, tt.want.result, result)
+			assert.Equal(t, tt.want.changed, changed)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
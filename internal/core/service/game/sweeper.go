@@ -0,0 +1,44 @@
+package game
+
+import (
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/port"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type sweeper struct {
+	service  port.GameService
+	interval time.Duration
+	stop     chan struct{}
+}
+
+// NewSweeper creates a sweeper that expires the timed games of the given service every interval
+func NewSweeper(service port.GameService, interval time.Duration) *sweeper {
+	return &sweeper{service: service, interval: interval, stop: make(chan struct{})}
+}
+
+// Start runs the sweeper in background until Stop is called
+func (swp *sweeper) Start() {
+	ticker := time.NewTicker(swp.interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := swp.service.ExpireGames(); err != nil {
+					log.Error(errors.String(err))
+				}
+			case <-swp.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the sweeper
+func (swp *sweeper) Stop() {
+	close(swp.stop)
+}
@@ -0,0 +1,103 @@
+package match
+
+import (
+	"fmt"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/port"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/clock"
+	"github.com/matiasvarela/minesweeper-API/pkg/random"
+)
+
+const (
+	minPlayers = 2
+	maxPlayers = 10
+)
+
+type service struct {
+	rnd         random.Random
+	clock       clock.Clock
+	repository  port.MatchRepository
+	gameService port.GameService
+	notifier    port.MatchNotifier
+}
+
+func NewService(rnd random.Random, clock clock.Clock, repository port.MatchRepository, gameService port.GameService, notifier port.MatchNotifier) *service {
+	return &service{
+		rnd:         rnd,
+		clock:       clock,
+		repository:  repository,
+		gameService: gameService,
+		notifier:    notifier,
+	}
+}
+
+// Get retrieves the match with the given matchID, as long as the userID given is one of its players
+func (srv *service) Get(userID string, matchID string) (domain.Match, error) {
+	match, err := srv.repository.Get(matchID)
+	if err != nil {
+		return domain.Match{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting match from repository")
+	}
+
+	if match == nil || !match.HasParticipant(userID) {
+		return domain.Match{}, errors.New(apperrors.NotFound, nil, "match has not been found", "")
+	}
+
+	return *match, nil
+}
+
+// Create starts a match between the given players, creating a game for each of them on the same board. The user that
+// creates it must be one of the players
+func (srv *service) Create(userID string, request domain.MatchRequest) (domain.Match, error) {
+	if len(request.UserIDs) < minPlayers || len(request.UserIDs) > maxPlayers {
+		return domain.Match{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the number of players must be between %d and %d", minPlayers, maxPlayers), "")
+	}
+
+	players := map[string]bool{}
+	for i, playerID := range request.UserIDs {
+		if playerID == "" {
+			return domain.Match{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("player %d has no user id", i), "")
+		}
+
+		if players[playerID] {
+			return domain.Match{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("player %d is repeated", i), "")
+		}
+
+		players[playerID] = true
+	}
+
+	if !players[userID] {
+		return domain.Match{}, errors.New(apperrors.Forbidden, nil, "a match can only be created by one of its players", "")
+	}
+
+	matchID := srv.rnd.GenerateID()
+
+	games, err := srv.gameService.CreateMatchGames(matchID, request.UserIDs, request.Settings)
+	if err != nil {
+		return domain.Match{}, errors.Wrap(err, err.Error())
+	}
+
+	match := domain.NewMatch(matchID, games, srv.clock.Now())
+	match.Version = 1
+
+	if err := srv.repository.Save(match); err != nil {
+		return domain.Match{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving match into repository")
+	}
+
+	return match, nil
+}
+
+// Subscribe returns the current state of the match, the channel where its next states are received and the function
+// that stops receiving them. Only the players of the match can follow it
+func (srv *service) Subscribe(userID string, matchID string) (domain.Match, <-chan domain.Match, func(), error) {
+	updates, unsubscribe := srv.notifier.Subscribe(matchID)
+
+	match, err := srv.Get(userID, matchID)
+	if err != nil {
+		unsubscribe()
+		return domain.Match{}, nil, nil, errors.Wrap(err, err.Error())
+	}
+
+	return match, updates, unsubscribe, nil
+}
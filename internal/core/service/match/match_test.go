@@ -0,0 +1,289 @@
+package match_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/service/match"
+	"github.com/matiasvarela/minesweeper-API/mock"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type dep struct {
+	rnd         *mock.MockRandom
+	clock       *mock.MockClock
+	repository  *mock.MockMatchRepository
+	gameService *mock.MockGameService
+	notifier    *mock.MockMatchNotifier
+}
+
+func newDep(t *testing.T) dep {
+	return dep{
+		rnd:         mock.NewMockRandom(gomock.NewController(t)),
+		clock:       mock.NewMockClock(gomock.NewController(t)),
+		repository:  mock.NewMockMatchRepository(gomock.NewController(t)),
+		gameService: mock.NewMockGameService(gomock.NewController(t)),
+		notifier:    mock.NewMockMatchNotifier(gomock.NewController(t)),
+	}
+}
+
+var mockedCreatedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestService_Get(t *testing.T) {
+	type args struct {
+		userID  string
+		matchID string
+	}
+	type want struct {
+		result domain.Match
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "get match successfully",
+			args: args{userID: "222", matchID: "m1"},
+			want: want{result: MockMatch("m1")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.matchID).Return(&want.result, nil)
+			},
+		},
+		{
+			name: "only the players can get the match",
+			args: args{userID: "333", matchID: "m1"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "match has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				match := MockMatch("m1")
+				dep.repository.EXPECT().Get(args.matchID).Return(&match, nil)
+			},
+		},
+		{
+			name: "match not found",
+			args: args{userID: "222", matchID: "m1"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "match has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.matchID).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at get from repository",
+			args: args{userID: "222", matchID: "m1"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting match from repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.matchID).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := match.NewService(dep.rnd, dep.clock, dep.repository, dep.gameService, dep.notifier)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Get(tt.args.userID, tt.args.matchID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	type args struct {
+		userID  string
+		request domain.MatchRequest
+	}
+	type want struct {
+		result domain.Match
+		err    error
+	}
+
+	settings := domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "create match successfully",
+			args: args{userID: "111", request: domain.MatchRequest{UserIDs: []string{"111", "222"}, Settings: settings}},
+			want: want{result: MockMatch("m1")},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return("m1")
+				dep.gameService.EXPECT().CreateMatchGames("m1", args.request.UserIDs, args.request.Settings).Return(MockMatchGames("m1"), nil)
+				dep.clock.EXPECT().Now().Return(mockedCreatedAt)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "too few players",
+			args: args{userID: "111", request: domain.MatchRequest{UserIDs: []string{"111"}, Settings: settings}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "the number of players must be between 2 and 10", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "player without user id",
+			args: args{userID: "111", request: domain.MatchRequest{UserIDs: []string{"111", ""}, Settings: settings}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "player 1 has no user id", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "repeated player",
+			args: args{userID: "111", request: domain.MatchRequest{UserIDs: []string{"111", "222", "111"}, Settings: settings}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "player 2 is repeated", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "the user is not one of the players",
+			args: args{userID: "333", request: domain.MatchRequest{UserIDs: []string{"111", "222"}, Settings: settings}},
+			want: want{err: errors.New(apperrors.Forbidden, nil, "a match can only be created by one of its players", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "fail at create the games",
+			args: args{userID: "111", request: domain.MatchRequest{UserIDs: []string{"111", "222"}, Settings: settings}},
+			want: want{err: errors.New(apperrors.InvalidInput, nil, "match games cannot be practice games", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return("m1")
+				dep.gameService.EXPECT().CreateMatchGames("m1", args.request.UserIDs, args.request.Settings).Return(nil, want.err)
+			},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{userID: "111", request: domain.MatchRequest{UserIDs: []string{"111", "222"}, Settings: settings}},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving match into repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return("m1")
+				dep.gameService.EXPECT().CreateMatchGames("m1", args.request.UserIDs, args.request.Settings).Return(MockMatchGames("m1"), nil)
+				dep.clock.EXPECT().Now().Return(mockedCreatedAt)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := match.NewService(dep.rnd, dep.clock, dep.repository, dep.gameService, dep.notifier)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Create(tt.args.userID, tt.args.request)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Subscribe(t *testing.T) {
+	type args struct {
+		userID  string
+		matchID string
+	}
+	type want struct {
+		result       domain.Match
+		unsubscribed bool
+		err          error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "subscribe to the match successfully",
+			args: args{userID: "222", matchID: "m1"},
+			want: want{result: MockMatch("m1")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.matchID).Return(&want.result, nil)
+			},
+		},
+		{
+			name: "only the players can follow the match",
+			args: args{userID: "333", matchID: "m1"},
+			want: want{unsubscribed: true, err: errors.New(apperrors.NotFound, nil, "match has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				match := MockMatch("m1")
+				dep.repository.EXPECT().Get(args.matchID).Return(&match, nil)
+			},
+		},
+		{
+			name: "match not found",
+			args: args{userID: "222", matchID: "m1"},
+			want: want{unsubscribed: true, err: errors.New(apperrors.NotFound, nil, "match has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.matchID).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := match.NewService(dep.rnd, dep.clock, dep.repository, dep.gameService, dep.notifier)
+
+			updates := make(chan domain.Match)
+			unsubscribed := false
+			dep.notifier.EXPECT().Subscribe(tt.args.matchID).Return((<-chan domain.Match)(updates), func() { unsubscribed = true })
+			tt.mock(dep, tt.args, tt.want)
+
+			result, received, _, err := service.Subscribe(tt.args.userID, tt.args.matchID)
+
+			assert.Equal(t, tt.want.result, result)
+			assert.Equal(t, tt.want.unsubscribed, unsubscribed)
+			if tt.want.err == nil {
+				assert.Equal(t, (<-chan domain.Match)(updates), received)
+			}
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func MockMatchGames(matchID string) []domain.Game {
+	settings := domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1, FirstClick: domain.FirstClickNone}
+
+	return []domain.Game{
+		{ID: "g1", UserID: "111", MatchID: matchID, Settings: settings, State: domain.GameStateNew},
+		{ID: "g2", UserID: "222", MatchID: matchID, Settings: settings, State: domain.GameStateNew},
+	}
+}
+
+func MockMatch(matchID string) domain.Match {
+	return domain.Match{
+		ID:       matchID,
+		Settings: domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1, FirstClick: domain.FirstClickNone},
+		Participants: []domain.MatchParticipant{
+			{UserID: "111", GameID: "g1", State: domain.GameStateNew},
+			{UserID: "222", GameID: "g2", State: domain.GameStateNew},
+		},
+		State:     domain.MatchStateOnGoing,
+		Version:   1,
+		CreatedAt: mockedCreatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package puzzle
+
+import (
+	"fmt"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/port"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/clock"
+	"github.com/matiasvarela/minesweeper-API/pkg/random"
+	"math"
+)
+
+const (
+	maxNameLength   = 100
+	leaderboardSize = 100
+)
+
+type service struct {
+	rnd            random.Random
+	clock          clock.Clock
+	repository     port.PuzzleRepository
+	gameRepository port.GameRepository
+	maxRows        int
+	maxColumns     int
+}
+
+type Option func(srv *service)
+
+func NewService(rnd random.Random, clock clock.Clock, repository port.PuzzleRepository, gameRepository port.GameRepository, options ...Option) *service {
+	srv := &service{
+		rnd:            rnd,
+		clock:          clock,
+		repository:     repository,
+		gameRepository: gameRepository,
+		maxRows:        math.MaxInt32,
+		maxColumns:     math.MaxInt32,
+	}
+	for _, option := range options {
+		option(srv)
+	}
+
+	return srv
+}
+
+// WithMaxBoardSize sets the greatest number of rows and columns of the puzzles that can be created. They are not
+// limited unless it is given
+func WithMaxBoardSize(rows int, columns int) Option {
+	return func(srv *service) {
+		srv.maxRows = rows
+		srv.maxColumns = columns
+	}
+}
+
+// Get retrieves the puzzle with the given puzzleID
+func (srv *service) Get(puzzleID string) (domain.Puzzle, error) {
+	puzzle, err := srv.repository.Get(puzzleID)
+	if err != nil {
+		return domain.Puzzle{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting puzzle from repository")
+	}
+
+	if puzzle == nil {
+		return domain.Puzzle{}, errors.New(apperrors.NotFound, nil, "puzzle has not been found", "")
+	}
+
+	return *puzzle, nil
+}
+
+// Create validates the board drawn in the design and saves it as a new puzzle with its mines fixed
+func (srv *service) Create(design domain.PuzzleDesign) (domain.Puzzle, error) {
+	if len([]rune(design.Name)) > maxNameLength {
+		return domain.Puzzle{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the name must have at most %d characters", maxNameLength), "")
+	}
+
+	if _, ok := domain.NewTopology(design.Topology); !ok {
+		return domain.Puzzle{}, errors.New(apperrors.InvalidInput, nil, "invalid topology", "")
+	}
+
+	if len(design.Layout) == 0 || len(design.Layout) > srv.maxRows {
+		return domain.Puzzle{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the number of rows must be between 1 and %d", srv.maxRows), "")
+	}
+
+	columns := len([]rune(design.Layout[0]))
+	if columns == 0 || columns > srv.maxColumns {
+		return domain.Puzzle{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the number of columns must be between 1 and %d", srv.maxColumns), "")
+	}
+
+	for i, line := range design.Layout {
+		if len([]rune(line)) != columns {
+			return domain.Puzzle{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("line %d of the layout must have as many characters as the first one", i), "")
+		}
+
+		for _, c := range line {
+			if c != domain.PuzzleLayoutMine && c != domain.MaskLayoutEnabled && c != domain.MaskLayoutDisabled {
+				return domain.Puzzle{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("line %d of the layout has an invalid character", i), "")
+			}
+		}
+	}
+
+	board := domain.NewPuzzleBoard(design.Layout)
+	if board.Count(domain.EmptyCellCovered) == 0 {
+		return domain.Puzzle{}, errors.New(apperrors.InvalidInput, nil, "the layout must have at least one cell without mine", "")
+	}
+
+	puzzle := domain.Puzzle{
+		ID:          srv.rnd.GenerateID(),
+		AuthorID:    design.AuthorID,
+		Name:        design.Name,
+		Topology:    design.Topology,
+		Rows:        len(board),
+		Columns:     columns,
+		BombsNumber: board.Count(domain.BombCellCovered),
+		Board:       board,
+		CreatedAt:   srv.clock.Now(),
+	}
+
+	if err := srv.repository.Save(puzzle); err != nil {
+		return domain.Puzzle{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving puzzle into repository")
+	}
+
+	return puzzle, nil
+}
+
+// Leaderboard ranks the players that have won the puzzle by their fastest game
+func (srv *service) Leaderboard(puzzleID string) ([]domain.LeaderboardEntry, error) {
+	puzzle, err := srv.Get(puzzleID)
+	if err != nil {
+		return nil, errors.Wrap(err, err.Error())
+	}
+
+	games, err := srv.gameRepository.GetAllByPuzzle(puzzleID)
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at searching games from repository")
+	}
+
+	return domain.NewLeaderboard(puzzle, games, leaderboardSize), nil
+}
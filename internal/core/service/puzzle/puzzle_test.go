@@ -0,0 +1,303 @@
+package puzzle_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/service/puzzle"
+	"github.com/matiasvarela/minesweeper-API/mock"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	e = domain.EmptyCellCovered
+	b = domain.BombCellCovered
+	D = domain.DisabledCell
+)
+
+type dep struct {
+	rnd            *mock.MockRandom
+	clock          *mock.MockClock
+	repository     *mock.MockPuzzleRepository
+	gameRepository *mock.MockGameRepository
+}
+
+func newDep(t *testing.T) dep {
+	return dep{
+		rnd:            mock.NewMockRandom(gomock.NewController(t)),
+		clock:          mock.NewMockClock(gomock.NewController(t)),
+		repository:     mock.NewMockPuzzleRepository(gomock.NewController(t)),
+		gameRepository: mock.NewMockGameRepository(gomock.NewController(t)),
+	}
+}
+
+func TestService_Get(t *testing.T) {
+	type args struct {
+		puzzleID string
+	}
+	type want struct {
+		result domain.Puzzle
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "get puzzle successfully",
+			args: args{puzzleID: "abc"},
+			want: want{result: MockPuzzle("abc")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.puzzleID).Return(&want.result, nil)
+			},
+		},
+		{
+			name: "puzzle not found",
+			args: args{puzzleID: "abc"},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.NotFound, nil, "puzzle has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.puzzleID).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at get from repository",
+			args: args{puzzleID: "abc"},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting puzzle from repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.puzzleID).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := puzzle.NewService(dep.rnd, dep.clock, dep.repository, dep.gameRepository)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Get(tt.args.puzzleID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	type args struct {
+		design domain.PuzzleDesign
+	}
+	type want struct {
+		result domain.Puzzle
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "create puzzle successfully",
+			args: args{design: domain.PuzzleDesign{AuthorID: "222", Name: "corners", Layout: []string{"*#.", "##*"}}},
+			want: want{result: MockPuzzle("abc")},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return(want.result.ID)
+				dep.clock.EXPECT().Now().Return(want.result.CreatedAt)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "invalid name",
+			args: args{design: domain.PuzzleDesign{Name: strings.Repeat("x", 101), Layout: []string{"*#"}}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.InvalidInput, nil, "the name must have at most 100 characters", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid topology",
+			args: args{design: domain.PuzzleDesign{Topology: "sphere", Layout: []string{"*#"}}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.InvalidInput, nil, "invalid topology", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid number of rows",
+			args: args{design: domain.PuzzleDesign{}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.InvalidInput, nil, "the number of rows must be between 1 and 800", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid number of columns",
+			args: args{design: domain.PuzzleDesign{Layout: []string{""}}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.InvalidInput, nil, "the number of columns must be between 1 and 800", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid length of a line of the layout",
+			args: args{design: domain.PuzzleDesign{Layout: []string{"*#.", "##"}}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.InvalidInput, nil, "line 1 of the layout must have as many characters as the first one", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "invalid character of the layout",
+			args: args{design: domain.PuzzleDesign{Layout: []string{"*#.", "#x*"}}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.InvalidInput, nil, "line 1 of the layout has an invalid character", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "layout without cells to reveal",
+			args: args{design: domain.PuzzleDesign{Layout: []string{"*.", "**"}}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.InvalidInput, nil, "the layout must have at least one cell without mine", "")},
+			mock: func(dep dep, args args, want want) {},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{design: domain.PuzzleDesign{AuthorID: "222", Name: "corners", Layout: []string{"*#.", "##*"}}},
+			want: want{result: domain.Puzzle{}, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving puzzle into repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.rnd.EXPECT().GenerateID().Return("abc")
+				dep.clock.EXPECT().Now().Return(mockedCreatedAt)
+				dep.repository.EXPECT().Save(MockPuzzle("abc")).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := puzzle.NewService(dep.rnd, dep.clock, dep.repository, dep.gameRepository, puzzle.WithMaxBoardSize(800, 800))
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Create(tt.args.design)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Create_WithMaxBoardSize(t *testing.T) {
+	dep := newDep(t)
+	service := puzzle.NewService(dep.rnd, dep.clock, dep.repository, dep.gameRepository, puzzle.WithMaxBoardSize(1, 2))
+
+	_, err := service.Create(domain.PuzzleDesign{Layout: []string{"*#", "##"}})
+	assert.Equal(t, "the number of rows must be between 1 and 1", err.Error())
+
+	_, err = service.Create(domain.PuzzleDesign{Layout: []string{"*##"}})
+	assert.Equal(t, "the number of columns must be between 1 and 2", err.Error())
+}
+
+func TestService_Leaderboard(t *testing.T) {
+	type args struct {
+		puzzleID string
+	}
+	type want struct {
+		result []domain.LeaderboardEntry
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "get leaderboard successfully",
+			args: args{puzzleID: "abc"},
+			want: want{result: []domain.LeaderboardEntry{
+				{Rank: 1, UserID: "333", GameID: "g2", Seconds: 20, Moves: 4, EndedAt: mockedCreatedAt.Add(20 * time.Second)},
+				{Rank: 2, UserID: "111", GameID: "g1", Seconds: 30, Moves: 5, EndedAt: mockedCreatedAt.Add(30 * time.Second)},
+			}},
+			mock: func(dep dep, args args, want want) {
+				puzzle := MockPuzzle(args.puzzleID)
+				dep.repository.EXPECT().Get(args.puzzleID).Return(&puzzle, nil)
+				dep.gameRepository.EXPECT().GetAllByPuzzle(args.puzzleID).Return([]domain.Game{
+					MockWonGame(args.puzzleID, "111", "g1", 30, 5),
+					MockWonGame(args.puzzleID, "333", "g2", 20, 4),
+					MockWonGame(args.puzzleID, "222", "g3", 10, 3),
+				}, nil)
+			},
+		},
+		{
+			name: "puzzle not found",
+			args: args{puzzleID: "abc"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "puzzle has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.puzzleID).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at search games from repository",
+			args: args{puzzleID: "abc"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at searching games from repository")},
+			mock: func(dep dep, args args, want want) {
+				puzzle := MockPuzzle(args.puzzleID)
+				dep.repository.EXPECT().Get(args.puzzleID).Return(&puzzle, nil)
+				dep.gameRepository.EXPECT().GetAllByPuzzle(args.puzzleID).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := puzzle.NewService(dep.rnd, dep.clock, dep.repository, dep.gameRepository)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Leaderboard(tt.args.puzzleID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+var mockedCreatedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func MockPuzzle(puzzleID string) domain.Puzzle {
+	return domain.Puzzle{
+		ID:          puzzleID,
+		AuthorID:    "222",
+		Name:        "corners",
+		Rows:        2,
+		Columns:     3,
+		BombsNumber: 2,
+		Board: domain.Board{
+			{b, e, D},
+			{e, e, b},
+		},
+		CreatedAt: mockedCreatedAt,
+	}
+}
+
+func MockWonGame(puzzleID string, userID string, gameID string, seconds int, moves int) domain.Game {
+	return domain.Game{
+		ID:        gameID,
+		UserID:    userID,
+		Settings:  domain.GameSettings{PuzzleID: puzzleID},
+		State:     domain.GameStateWon,
+		Moves:     moves,
+		StartedAt: mockedCreatedAt,
+		EndedAt:   mockedCreatedAt.Add(time.Duration(seconds) * time.Second),
+	}
+}
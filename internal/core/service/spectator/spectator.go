@@ -0,0 +1,159 @@
+package spectator
+
+import (
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/port"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/clock"
+	"github.com/matiasvarela/minesweeper-API/pkg/random"
+)
+
+type service struct {
+	rnd         random.Random
+	clock       clock.Clock
+	repository  port.ShareLinkRepository
+	gameService port.GameService
+	notifier    port.GameNotifier
+}
+
+func NewService(rnd random.Random, clock clock.Clock, repository port.ShareLinkRepository, gameService port.GameService, notifier port.GameNotifier) *service {
+	return &service{
+		rnd:         rnd,
+		clock:       clock,
+		repository:  repository,
+		gameService: gameService,
+		notifier:    notifier,
+	}
+}
+
+// Share creates a link to watch the given game. Any participant of the game can create links
+func (srv *service) Share(userID string, gameID string) (domain.ShareLink, error) {
+	if _, err := srv.gameService.Get(userID, gameID); err != nil {
+		return domain.ShareLink{}, errors.Wrap(err, err.Error())
+	}
+
+	link := domain.ShareLink{
+		Token:     srv.rnd.GenerateID(),
+		GameID:    gameID,
+		UserID:    userID,
+		CreatedAt: srv.clock.Now(),
+	}
+
+	if err := srv.repository.Save(link); err != nil {
+		return domain.ShareLink{}, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving share link into repository")
+	}
+
+	return link, nil
+}
+
+// Revoke deletes the given link of the game, so it cannot be used to watch the game anymore. Only the owner of the game
+// and the participant that created the link can revoke it
+func (srv *service) Revoke(userID string, gameID string, token string) error {
+	link, err := srv.getLink(token)
+	if err != nil {
+		return errors.Wrap(err, err.Error())
+	}
+
+	if link.GameID != gameID {
+		return errors.New(apperrors.NotFound, nil, "share link has not been found", "")
+	}
+
+	game, err := srv.gameService.Get(userID, gameID)
+	if err != nil {
+		return errors.Wrap(err, err.Error())
+	}
+
+	if game.UserID != userID && link.UserID != userID {
+		return errors.New(apperrors.Forbidden, nil, "only the owner of the game or the creator of the link can revoke it", "")
+	}
+
+	if err := srv.repository.Delete(token); err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at deleting share link from repository")
+	}
+
+	srv.notifier.Cancel(token)
+
+	return nil
+}
+
+// Get retrieves the game shared by the given link as seen by its spectators
+func (srv *service) Get(token string) (domain.SpectatorView, error) {
+	link, err := srv.getLink(token)
+	if err != nil {
+		return domain.SpectatorView{}, errors.Wrap(err, err.Error())
+	}
+
+	view, err := srv.view(*link)
+	if err != nil {
+		return domain.SpectatorView{}, errors.Wrap(err, err.Error())
+	}
+
+	return view, nil
+}
+
+// Subscribe returns the game shared by the given link as seen by its spectators and the channel where its new states are
+// received, until the returned function is called or the link is revoked
+func (srv *service) Subscribe(token string) (domain.SpectatorView, <-chan domain.SpectatorView, func(), error) {
+	link, err := srv.getLink(token)
+	if err != nil {
+		return domain.SpectatorView{}, nil, nil, errors.Wrap(err, err.Error())
+	}
+
+	updates, unsubscribe := srv.notifier.Subscribe(link.GameID, token)
+
+	view, err := srv.view(*link)
+	if err != nil {
+		unsubscribe()
+		return domain.SpectatorView{}, nil, nil, errors.Wrap(err, err.Error())
+	}
+
+	views := make(chan domain.SpectatorView, 1)
+	go srv.watch(updates, views)
+
+	return view, views, unsubscribe, nil
+}
+
+// watch turns the new states of the game into the views sent to the spectators, keeping only the latest one as the
+// notifier does. It stops once the subscription is stopped, either by the spectator or by revoking the link
+func (srv *service) watch(updates <-chan domain.Game, views chan domain.SpectatorView) {
+	defer close(views)
+
+	for game := range updates {
+		select {
+		case <-views:
+		default:
+		}
+
+		views <- domain.NewSpectatorView(game, srv.clock.Now())
+	}
+}
+
+// getLink retrieves the link with the given token
+func (srv *service) getLink(token string) (*domain.ShareLink, error) {
+	link, err := srv.repository.Get(token)
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting share link from repository")
+	}
+
+	if link == nil {
+		return nil, errors.New(apperrors.NotFound, nil, "share link has not been found", "")
+	}
+
+	return link, nil
+}
+
+// view retrieves the game shared by the given link as seen by its spectators. The game is read as the participant that
+// created the link, so the link stops working if that user leaves the game
+func (srv *service) view(link domain.ShareLink) (domain.SpectatorView, error) {
+	game, err := srv.gameService.Get(link.UserID, link.GameID)
+	if errors.Code(err) == errors.Code(apperrors.NotFound) {
+		return domain.SpectatorView{}, errors.New(apperrors.NotFound, err, "share link has not been found", "")
+	}
+
+	if err != nil {
+		return domain.SpectatorView{}, errors.Wrap(err, err.Error())
+	}
+
+	return domain.NewSpectatorView(game, srv.clock.Now()), nil
+}
@@ -0,0 +1,318 @@
+package spectator_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/service/spectator"
+	"github.com/matiasvarela/minesweeper-API/mock"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type dep struct {
+	rnd         *mock.MockRandom
+	clock       *mock.MockClock
+	repository  *mock.MockShareLinkRepository
+	gameService *mock.MockGameService
+	notifier    *mock.MockGameNotifier
+}
+
+func newDep(t *testing.T) dep {
+	return dep{
+		rnd:         mock.NewMockRandom(gomock.NewController(t)),
+		clock:       mock.NewMockClock(gomock.NewController(t)),
+		repository:  mock.NewMockShareLinkRepository(gomock.NewController(t)),
+		gameService: mock.NewMockGameService(gomock.NewController(t)),
+		notifier:    mock.NewMockGameNotifier(gomock.NewController(t)),
+	}
+}
+
+var (
+	mockedNow  = time.Date(2020, 1, 1, 0, 0, 30, 0, time.UTC)
+	mockedLink = domain.ShareLink{Token: "t1", GameID: "xyz", UserID: "222", CreatedAt: mockedNow}
+)
+
+func TestService_Share(t *testing.T) {
+	type args struct {
+		userID string
+		gameID string
+	}
+	type want struct {
+		result domain.ShareLink
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "share game successfully",
+			args: args{userID: "222", gameID: "xyz"},
+			want: want{result: mockedLink},
+			mock: func(dep dep, args args, want want) {
+				dep.gameService.EXPECT().Get(args.userID, args.gameID).Return(MockGame(domain.GameStateOnGoing), nil)
+				dep.rnd.EXPECT().GenerateID().Return("t1")
+				dep.clock.EXPECT().Now().Return(mockedNow)
+				dep.repository.EXPECT().Save(want.result).Return(nil)
+			},
+		},
+		{
+			name: "game not found",
+			args: args{userID: "333", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "game has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.gameService.EXPECT().Get(args.userID, args.gameID).Return(domain.Game{}, want.err)
+			},
+		},
+		{
+			name: "fail at save into repository",
+			args: args{userID: "222", gameID: "xyz"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving share link into repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.gameService.EXPECT().Get(args.userID, args.gameID).Return(MockGame(domain.GameStateOnGoing), nil)
+				dep.rnd.EXPECT().GenerateID().Return("t1")
+				dep.clock.EXPECT().Now().Return(mockedNow)
+				dep.repository.EXPECT().Save(gomock.Any()).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := spectator.NewService(dep.rnd, dep.clock, dep.repository, dep.gameService, dep.notifier)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Share(tt.args.userID, tt.args.gameID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Revoke(t *testing.T) {
+	type args struct {
+		userID string
+		gameID string
+		token  string
+	}
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "creator of the link revokes it successfully",
+			args: args{userID: "222", gameID: "xyz", token: "t1"},
+			mock: func(dep dep, args args, want want) {
+				link := mockedLink
+				dep.repository.EXPECT().Get(args.token).Return(&link, nil)
+				dep.gameService.EXPECT().Get(args.userID, args.gameID).Return(MockGame(domain.GameStateOnGoing), nil)
+				dep.repository.EXPECT().Delete(args.token).Return(nil)
+				dep.notifier.EXPECT().Cancel(args.token)
+			},
+		},
+		{
+			name: "owner of the game revokes it successfully",
+			args: args{userID: "111", gameID: "xyz", token: "t1"},
+			mock: func(dep dep, args args, want want) {
+				link := mockedLink
+				dep.repository.EXPECT().Get(args.token).Return(&link, nil)
+				dep.gameService.EXPECT().Get(args.userID, args.gameID).Return(MockGame(domain.GameStateOnGoing), nil)
+				dep.repository.EXPECT().Delete(args.token).Return(nil)
+				dep.notifier.EXPECT().Cancel(args.token)
+			},
+		},
+		{
+			name: "other participants cannot revoke the link",
+			args: args{userID: "333", gameID: "xyz", token: "t1"},
+			want: want{err: errors.New(apperrors.Forbidden, nil, "only the owner of the game or the creator of the link can revoke it", "")},
+			mock: func(dep dep, args args, want want) {
+				link := mockedLink
+				dep.repository.EXPECT().Get(args.token).Return(&link, nil)
+				dep.gameService.EXPECT().Get(args.userID, args.gameID).Return(MockGame(domain.GameStateOnGoing), nil)
+			},
+		},
+		{
+			name: "link of another game",
+			args: args{userID: "222", gameID: "abc", token: "t1"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "share link has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				link := mockedLink
+				dep.repository.EXPECT().Get(args.token).Return(&link, nil)
+			},
+		},
+		{
+			name: "link not found",
+			args: args{userID: "222", gameID: "xyz", token: "t1"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "share link has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.token).Return(nil, nil)
+			},
+		},
+		{
+			name: "fail at delete from repository",
+			args: args{userID: "222", gameID: "xyz", token: "t1"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at deleting share link from repository")},
+			mock: func(dep dep, args args, want want) {
+				link := mockedLink
+				dep.repository.EXPECT().Get(args.token).Return(&link, nil)
+				dep.gameService.EXPECT().Get(args.userID, args.gameID).Return(MockGame(domain.GameStateOnGoing), nil)
+				dep.repository.EXPECT().Delete(args.token).Return(apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := spectator.NewService(dep.rnd, dep.clock, dep.repository, dep.gameService, dep.notifier)
+			tt.mock(dep, tt.args, tt.want)
+			err := service.Revoke(tt.args.userID, tt.args.gameID, tt.args.token)
+
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Get(t *testing.T) {
+	type args struct {
+		token string
+	}
+	type want struct {
+		result domain.SpectatorView
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args, want)
+	}{
+		{
+			name: "get the game as seen by the spectators successfully",
+			args: args{token: "t1"},
+			want: want{result: domain.NewSpectatorView(MockGame(domain.GameStateOnGoing), mockedNow)},
+			mock: func(dep dep, args args, want want) {
+				link := mockedLink
+				dep.repository.EXPECT().Get(args.token).Return(&link, nil)
+				dep.gameService.EXPECT().Get("222", "xyz").Return(MockGame(domain.GameStateOnGoing), nil)
+				dep.clock.EXPECT().Now().Return(mockedNow)
+			},
+		},
+		{
+			name: "link not found",
+			args: args{token: "t1"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "share link has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.token).Return(nil, nil)
+			},
+		},
+		{
+			name: "the creator of the link has left the game",
+			args: args{token: "t1"},
+			want: want{err: errors.New(apperrors.NotFound, nil, "share link has not been found", "")},
+			mock: func(dep dep, args args, want want) {
+				link := mockedLink
+				dep.repository.EXPECT().Get(args.token).Return(&link, nil)
+				dep.gameService.EXPECT().Get("222", "xyz").Return(domain.Game{}, errors.New(apperrors.NotFound, nil, "game has not been found", ""))
+			},
+		},
+		{
+			name: "fail at get from repository",
+			args: args{token: "t1"},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting share link from repository")},
+			mock: func(dep dep, args args, want want) {
+				dep.repository.EXPECT().Get(args.token).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			service := spectator.NewService(dep.rnd, dep.clock, dep.repository, dep.gameService, dep.notifier)
+			tt.mock(dep, tt.args, tt.want)
+			result, err := service.Get(tt.args.token)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestService_Subscribe(t *testing.T) {
+	// Setup
+	dep := newDep(t)
+	service := spectator.NewService(dep.rnd, dep.clock, dep.repository, dep.gameService, dep.notifier)
+
+	link := mockedLink
+	updates := make(chan domain.Game, 1)
+	unsubscribed := false
+	won := MockGame(domain.GameStateWon)
+
+	dep.clock.EXPECT().Now().Return(mockedNow).AnyTimes()
+	dep.notifier.EXPECT().Subscribe("xyz", "t1").Return((<-chan domain.Game)(updates), func() {
+		if !unsubscribed {
+			unsubscribed = true
+			close(updates)
+		}
+	})
+	dep.gameService.EXPECT().Get("222", "xyz").Return(MockGame(domain.GameStateOnGoing), nil)
+	dep.repository.EXPECT().Get("t1").Return(&link, nil)
+
+	// Execute
+	view, views, unsubscribe, err := service.Subscribe("t1")
+
+	// Verify
+	assert.Nil(t, err)
+	assert.Equal(t, domain.NewSpectatorView(MockGame(domain.GameStateOnGoing), mockedNow), view)
+
+	updates <- won
+	assert.Equal(t, domain.NewSpectatorView(won, mockedNow), <-views)
+
+	unsubscribe()
+	_, ok := <-views
+	assert.False(t, ok, "the views are no longer sent once the subscription has been stopped")
+	assert.True(t, unsubscribed)
+}
+
+func MockGame(state string) domain.Game {
+	return domain.Game{
+		ID:             "xyz",
+		UserID:         "111",
+		Participants:   []string{"222", "333"},
+		Board:          domain.Board{{domain.EmptyCellRevealed, domain.BombCellCovered}, {domain.EmptyCellCovered, domain.EmptyCellCovered}},
+		Settings:       domain.GameSettings{Rows: 2, Columns: 2, BombsNumber: 1},
+		State:          state,
+		RemainingLives: 1,
+		StartedAt:      mockedNow.Add(-10 * time.Second),
+	}
+}
@@ -7,8 +7,26 @@ import (
 )
 
 type Dep struct {
-	DynamoDB       dynamodbiface.DynamoDB
-	GameService    port.GameService
-	GameHandler    *handler.GameHandler
-	GameRepository port.GameRepository
+	DynamoDB            dynamodbiface.DynamoDB
+	GameService         port.GameService
+	GameHandler         *handler.GameHandler
+	GameRepository      port.GameRepository
+	GameSweeper         Sweeper
+	PuzzleService       port.PuzzleService
+	PuzzleHandler       *handler.PuzzleHandler
+	PuzzleRepository    port.PuzzleRepository
+	MatchService        port.MatchService
+	MatchHandler        *handler.MatchHandler
+	MatchRepository     port.MatchRepository
+	MatchNotifier       port.MatchNotifier
+	GameNotifier        port.GameNotifier
+	SpectatorService    port.SpectatorService
+	SpectatorHandler    *handler.SpectatorHandler
+	ShareLinkRepository port.ShareLinkRepository
+	AuthHandler         *handler.AuthHandler
+}
+
+type Sweeper interface {
+	Start()
+	Stop()
 }
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/pkg/apierror"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/auth"
+	log "github.com/sirupsen/logrus"
+	"strings"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	userIDKey    = "user_id"
+)
+
+type AuthHandler struct {
+	verifier auth.Verifier
+}
+
+func NewAuthHandler(verifier auth.Verifier) *AuthHandler {
+	return &AuthHandler{verifier: verifier}
+}
+
+// Authenticate is the middleware that requires a valid bearer token. The user the token has been issued to is kept in
+// the context and, when the path refers to a user, it must be the same one
+func (hdl *AuthHandler) Authenticate(request *gin.Context) {
+	header := request.GetHeader("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		hdl.abort(request, errors.New(apperrors.Unauthorized, nil, "a bearer token is required", ""))
+		return
+	}
+
+	userID, err := hdl.verifier.Verify(strings.TrimPrefix(header, bearerPrefix))
+	if err != nil {
+		hdl.abort(request, err)
+		return
+	}
+
+	if pathUserID := request.Param("user_id"); pathUserID != "" && pathUserID != userID {
+		hdl.abort(request, errors.New(apperrors.Forbidden, nil, "the token has not been issued to the user of the path", ""))
+		return
+	}
+
+	request.Set(userIDKey, userID)
+	request.Next()
+}
+
+// authenticatedUserID returns the id of the user the token of the request has been issued to, as kept by Authenticate
+func authenticatedUserID(request *gin.Context) string {
+	return request.GetString(userIDKey)
+}
+
+func (hdl *AuthHandler) abort(request *gin.Context, err error) {
+	log.Error(errors.String(err))
+
+	if errors.Code(err) == errors.Code(apperrors.Unauthorized) {
+		request.Header("WWW-Authenticate", `Bearer realm="minesweeper"`)
+	}
+
+	request.AbortWithStatusJSON(apierror.New(err))
+}
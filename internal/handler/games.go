@@ -1,22 +1,79 @@
 package handler
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/matiasvarela/errors"
 	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
 	"github.com/matiasvarela/minesweeper-API/internal/core/port"
 	"github.com/matiasvarela/minesweeper-API/pkg/apierror"
 	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/clock"
+	"github.com/matiasvarela/minesweeper-API/pkg/render"
 	log "github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
+)
+
+const (
+	defaultCellSize = 24
+	minCellSize     = 4
+	maxCellSize     = 128
 )
 
 type GameHandler struct {
 	gameService port.GameService
+	clock       clock.Clock
+}
+
+// gameResponse is the game as seen by the clients: bombs hidden and progress computed
+type gameResponse struct {
+	domain.Game
+	Progress domain.Progress `json:"progress"`
+}
+
+// diffResponse is the outcome of an action as seen by the clients: only the cells that changed and the new state
+type diffResponse struct {
+	State          string          `json:"state"`
+	RemainingLives int             `json:"remaining_lives"`
+	Progress       domain.Progress `json:"progress"`
+	Cells          []cellResponse  `json:"cells"`
+}
+
+type cellResponse struct {
+	domain.Position
+	Cell domain.Cell `json:"cell"`
+}
+
+// cellRequest is the cell an action is applied to, given either by row and column or, for hexagonal boards, by its
+// axial coordinates q and r
+type cellRequest struct {
+	Row    int  `json:"row"`
+	Column int  `json:"column"`
+	Q      *int `json:"q"`
+	R      *int `json:"r"`
+}
+
+// actionRequest is an action of the batch of actions, with its cell given as in the single cell actions
+type actionRequest struct {
+	Type string `json:"type"`
+	cellRequest
+}
+
+// coordinates returns the coordinates of the cell in the system it has been given in
+func (body cellRequest) coordinates() (domain.Coordinates, error) {
+	switch {
+	case body.Q == nil && body.R == nil:
+		return domain.NewOffsetCoordinates(body.Row, body.Column), nil
+	case body.Q != nil && body.R != nil:
+		return domain.NewAxialCoordinates(*body.Q, *body.R), nil
+	default:
+		return domain.Coordinates{}, errors.New(apperrors.InvalidInput, nil, "both q and r axial coordinates are required", "")
+	}
 }
 
-func NewGameHandler(gameService port.GameService) *GameHandler {
-	return &GameHandler{gameService: gameService}
+func NewGameHandler(gameService port.GameService, clock clock.Clock) *GameHandler {
+	return &GameHandler{gameService: gameService, clock: clock}
 }
 
 func (hdl *GameHandler) Get(request *gin.Context) {
@@ -27,9 +84,17 @@ func (hdl *GameHandler) Get(request *gin.Context) {
 		return
 	}
 
-	game.Board.HideBombs()
+	if wantsText(request) {
+		charset := render.ASCII
+		if request.Query("charset") == "unicode" {
+			charset = render.Unicode
+		}
 
-	request.JSON(http.StatusOK, game)
+		request.String(http.StatusOK, render.Text(game.Board, game.Topology(), game.IsFinished(), charset))
+		return
+	}
+
+	request.JSON(http.StatusOK, hdl.present(game))
 }
 
 func (hdl *GameHandler) GetAll(request *gin.Context) {
@@ -40,11 +105,12 @@ func (hdl *GameHandler) GetAll(request *gin.Context) {
 		return
 	}
 
+	response := make([]gameResponse, len(games))
 	for i := range games {
-		games[i].Board.HideBombs()
+		response[i] = hdl.present(games[i])
 	}
 
-	request.JSON(http.StatusOK, games)
+	request.JSON(http.StatusOK, response)
 }
 
 func (hdl *GameHandler) Create(request *gin.Context) {
@@ -63,15 +129,88 @@ func (hdl *GameHandler) Create(request *gin.Context) {
 		return
 	}
 
-	game.Board.HideBombs()
-
-	request.JSON(http.StatusCreated, game)
+	request.JSON(http.StatusCreated, hdl.present(game))
 }
 
 func (hdl *GameHandler) Mark(request *gin.Context) {
+	body := cellRequest{}
+	if err := request.BindJSON(&body); err != nil {
+		err = errors.New(apperrors.InvalidInput, err, "invalid body", "failed at bind json body")
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	coordinates, err := body.coordinates()
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	diff, err := wantsDiff(request)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	game, changed, err := hdl.gameService.MarkCell(request.Param("user_id"), request.Param("game_id"), coordinates)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	if diff {
+		request.JSON(http.StatusOK, hdl.presentDiff(game, changed))
+		return
+	}
+
+	request.JSON(http.StatusOK, hdl.present(game))
+}
+
+func (hdl *GameHandler) Reveal(request *gin.Context) {
+	body := cellRequest{}
+	if err := request.BindJSON(&body); err != nil {
+		err = errors.New(apperrors.InvalidInput, err, "invalid body", "failed at bind json body")
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	coordinates, err := body.coordinates()
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	diff, err := wantsDiff(request)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	game, changed, err := hdl.gameService.RevealCell(request.Param("user_id"), request.Param("game_id"), coordinates)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	if diff {
+		request.JSON(http.StatusOK, hdl.presentDiff(game, changed))
+		return
+	}
+
+	request.JSON(http.StatusOK, hdl.present(game))
+}
+
+func (hdl *GameHandler) Act(request *gin.Context) {
 	body := struct {
-		Row    int `json:"row"`
-		Column int `json:"column"`
+		Actions []actionRequest `json:"actions"`
 	}{}
 	if err := request.BindJSON(&body); err != nil {
 		err = errors.New(apperrors.InvalidInput, err, "invalid body", "failed at bind json body")
@@ -80,22 +219,71 @@ func (hdl *GameHandler) Mark(request *gin.Context) {
 		return
 	}
 
-	game, err := hdl.gameService.MarkCell(request.Param("user_id"), request.Param("game_id"), body.Row, body.Column)
+	actions := make([]domain.Action, len(body.Actions))
+	for i, action := range body.Actions {
+		coordinates, err := action.coordinates()
+		if err != nil {
+			err = errors.New(apperrors.InvalidInput, err, fmt.Sprintf("%s of action %d", err.Error(), i), "")
+			log.Error(errors.String(err))
+			request.AbortWithStatusJSON(apierror.New(err))
+			return
+		}
+
+		actions[i] = domain.Action{Type: action.Type, Coordinates: coordinates}
+	}
+
+	game, results, err := hdl.gameService.Act(request.Param("user_id"), request.Param("game_id"), actions)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusOK, struct {
+		Results []domain.ActionResult `json:"results"`
+		Game    gameResponse          `json:"game"`
+	}{Results: results, Game: hdl.present(game)})
+}
+
+func (hdl *GameHandler) Hint(request *gin.Context) {
+	game, hint, err := hdl.gameService.Hint(request.Param("user_id"), request.Param("game_id"))
 	if err != nil {
 		log.Error(errors.String(err))
 		request.AbortWithStatusJSON(apierror.New(err))
 		return
 	}
 
-	game.Board.HideBombs()
+	request.JSON(http.StatusOK, struct {
+		Hint domain.Hint  `json:"hint"`
+		Game gameResponse `json:"game"`
+	}{Hint: hint, Game: hdl.present(game)})
+}
+
+func (hdl *GameHandler) Undo(request *gin.Context) {
+	game, err := hdl.gameService.Undo(request.Param("user_id"), request.Param("game_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
 
-	request.JSON(http.StatusOK, game)
+	request.JSON(http.StatusOK, hdl.present(game))
 }
 
-func (hdl *GameHandler) Reveal(request *gin.Context) {
+func (hdl *GameHandler) Retry(request *gin.Context) {
+	game, err := hdl.gameService.Retry(request.Param("user_id"), request.Param("game_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusCreated, hdl.present(game))
+}
+
+func (hdl *GameHandler) AddParticipant(request *gin.Context) {
 	body := struct {
-		Row    int `json:"row"`
-		Column int `json:"column"`
+		UserID string `json:"user_id"`
 	}{}
 	if err := request.BindJSON(&body); err != nil {
 		err = errors.New(apperrors.InvalidInput, err, "invalid body", "failed at bind json body")
@@ -104,14 +292,142 @@ func (hdl *GameHandler) Reveal(request *gin.Context) {
 		return
 	}
 
-	game, err := hdl.gameService.RevealCell(request.Param("user_id"), request.Param("game_id"), body.Row, body.Column)
+	game, err := hdl.gameService.AddParticipant(request.Param("user_id"), request.Param("game_id"), body.UserID)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusOK, hdl.present(game))
+}
+
+func (hdl *GameHandler) RemoveParticipant(request *gin.Context) {
+	game, err := hdl.gameService.RemoveParticipant(request.Param("user_id"), request.Param("game_id"), request.Param("participant_id"))
 	if err != nil {
 		log.Error(errors.String(err))
 		request.AbortWithStatusJSON(apierror.New(err))
 		return
 	}
 
+	request.JSON(http.StatusOK, hdl.present(game))
+}
+
+func (hdl *GameHandler) Analyze(request *gin.Context) {
+	analysis, err := hdl.gameService.Analyze(request.Param("user_id"), request.Param("game_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusOK, analysis)
+}
+
+func (hdl *GameHandler) ImageSVG(request *gin.Context) {
+	game, cellSize, theme, err := hdl.getImageParams(request)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.Data(http.StatusOK, "image/svg+xml", []byte(render.SVG(game.Board, game.Topology(), game.IsFinished(), cellSize, theme)))
+}
+
+func (hdl *GameHandler) ImagePNG(request *gin.Context) {
+	game, cellSize, theme, err := hdl.getImageParams(request)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.Status(http.StatusOK)
+	request.Header("Content-Type", "image/png")
+	if err := render.PNG(request.Writer, game.Board, game.Topology(), game.IsFinished(), cellSize, theme); err != nil {
+		log.Error(errors.String(errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at encoding png image")))
+	}
+}
+
+// present computes the progress of the game and hides its bombs, so every endpoint responds the same way.
+// The undo history is left out since it holds the boards with their bombs
+func (hdl *GameHandler) present(game domain.Game) gameResponse {
+	progress := game.Progress(hdl.clock.Now())
 	game.Board.HideBombs()
+	game.History = nil
+
+	return gameResponse{Game: game, Progress: progress}
+}
+
+// presentDiff computes the progress of the game and the new value of the cells that changed, hiding their bombs
+func (hdl *GameHandler) presentDiff(game domain.Game, changed []domain.Position) diffResponse {
+	cells := make([]cellResponse, len(changed))
+	for i, pos := range changed {
+		cells[i] = cellResponse{Position: pos, Cell: game.Board.Get(pos).Hide()}
+	}
+
+	return diffResponse{
+		State:          game.State,
+		RemainingLives: game.RemainingLives,
+		Progress:       game.Progress(hdl.clock.Now()),
+		Cells:          cells,
+	}
+}
+
+// getImageParams retrieves the game and the cell size and theme used to draw it. The default cell size is shrunk to
+// keep the image of big boards within the pixels allowed, while a cell size given explicitly is rejected
+func (hdl *GameHandler) getImageParams(request *gin.Context) (domain.Game, int, render.Theme, error) {
+	cellSize, explicit := defaultCellSize, false
+	if value := request.Query("cell_size"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < minCellSize || size > maxCellSize {
+			return domain.Game{}, 0, render.Theme{}, errors.New(apperrors.InvalidInput, err, "invalid cell size", "")
+		}
+
+		cellSize, explicit = size, true
+	}
+
+	theme, ok := render.ThemeByName(request.Query("theme"))
+	if !ok {
+		return domain.Game{}, 0, render.Theme{}, errors.New(apperrors.InvalidInput, nil, "invalid theme", "")
+	}
+
+	game, err := hdl.gameService.Get(request.Param("user_id"), request.Param("game_id"))
+	if err != nil {
+		return domain.Game{}, 0, render.Theme{}, errors.Wrap(err, err.Error())
+	}
+
+	limit := render.MaxCellSize(game.Topology(), game.Settings.Rows, game.Settings.Columns)
+	switch {
+	case limit < minCellSize:
+		return domain.Game{}, 0, render.Theme{}, errors.New(apperrors.InvalidInput, nil, "the board is too big to be drawn as an image", "")
+	case cellSize > limit && explicit:
+		return domain.Game{}, 0, render.Theme{}, errors.New(apperrors.InvalidInput, nil, fmt.Sprintf("the cell size of this board must be at most %d", limit), "")
+	case cellSize > limit:
+		cellSize = limit
+	}
+
+	return game, cellSize, theme, nil
+}
+
+// wantsDiff returns true if the client asked for only the cells changed by an action instead of the whole game
+func wantsDiff(request *gin.Context) (bool, error) {
+	switch request.Query("response") {
+	case "", "game":
+		return false, nil
+	case "diff":
+		return true, nil
+	default:
+		return false, errors.New(apperrors.InvalidInput, nil, "invalid response", "")
+	}
+}
+
+// wantsText returns true if the client asked for the board rendered as text, either by query or by Accept header
+func wantsText(request *gin.Context) bool {
+	if format := request.Query("format"); format != "" {
+		return format == "text"
+	}
 
-	request.JSON(http.StatusOK, game)
+	return request.NegotiateFormat(gin.MIMEJSON, gin.MIMEPlain) == gin.MIMEPlain
 }
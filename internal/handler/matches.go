@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/port"
+	"github.com/matiasvarela/minesweeper-API/pkg/apierror"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+)
+
+const (
+	matchEvent = "match"
+)
+
+type MatchHandler struct {
+	matchService port.MatchService
+}
+
+func NewMatchHandler(matchService port.MatchService) *MatchHandler {
+	return &MatchHandler{matchService: matchService}
+}
+
+func (hdl *MatchHandler) Get(request *gin.Context) {
+	match, err := hdl.matchService.Get(authenticatedUserID(request), request.Param("match_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusOK, match)
+}
+
+func (hdl *MatchHandler) Create(request *gin.Context) {
+	body := domain.MatchRequest{}
+	if err := request.BindJSON(&body); err != nil {
+		err = errors.New(apperrors.InvalidInput, err, "invalid body", "failed at bind json body")
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	match, err := hdl.matchService.Create(authenticatedUserID(request), body)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusCreated, match)
+}
+
+// Events streams the state of the match to one of its players as server-sent events: the current one first and then
+// every new one, until the client goes away
+func (hdl *MatchHandler) Events(request *gin.Context) {
+	match, updates, unsubscribe, err := hdl.matchService.Subscribe(request.Param("user_id"), request.Param("match_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+	defer unsubscribe()
+
+	request.SSEvent(matchEvent, match)
+	request.Stream(func(w io.Writer) bool {
+		select {
+		case match, ok := <-updates:
+			if !ok {
+				return false
+			}
+
+			request.SSEvent(matchEvent, match)
+			return true
+		case <-request.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/core/port"
+	"github.com/matiasvarela/minesweeper-API/pkg/apierror"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type PuzzleHandler struct {
+	puzzleService port.PuzzleService
+}
+
+func NewPuzzleHandler(puzzleService port.PuzzleService) *PuzzleHandler {
+	return &PuzzleHandler{puzzleService: puzzleService}
+}
+
+func (hdl *PuzzleHandler) Get(request *gin.Context) {
+	puzzle, err := hdl.puzzleService.Get(request.Param("puzzle_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusOK, hdl.present(puzzle))
+}
+
+func (hdl *PuzzleHandler) Create(request *gin.Context) {
+	body := domain.PuzzleDesign{}
+	if err := request.BindJSON(&body); err != nil {
+		err = errors.New(apperrors.InvalidInput, err, "invalid body", "failed at bind json body")
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	body.AuthorID = authenticatedUserID(request)
+
+	puzzle, err := hdl.puzzleService.Create(body)
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusCreated, hdl.present(puzzle))
+}
+
+func (hdl *PuzzleHandler) Leaderboard(request *gin.Context) {
+	leaderboard, err := hdl.puzzleService.Leaderboard(request.Param("puzzle_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusOK, leaderboard)
+}
+
+// present hides the mines of the puzzle, so that sharing it does not spoil it
+func (hdl *PuzzleHandler) present(puzzle domain.Puzzle) domain.Puzzle {
+	puzzle.Board = puzzle.Board.Copy()
+	puzzle.Board.HideBombs()
+
+	return puzzle
+}
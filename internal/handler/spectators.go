@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/port"
+	"github.com/matiasvarela/minesweeper-API/pkg/apierror"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+)
+
+const (
+	gameEvent = "game"
+)
+
+type SpectatorHandler struct {
+	spectatorService port.SpectatorService
+}
+
+func NewSpectatorHandler(spectatorService port.SpectatorService) *SpectatorHandler {
+	return &SpectatorHandler{spectatorService: spectatorService}
+}
+
+func (hdl *SpectatorHandler) Share(request *gin.Context) {
+	link, err := hdl.spectatorService.Share(request.Param("user_id"), request.Param("game_id"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusCreated, link)
+}
+
+func (hdl *SpectatorHandler) Revoke(request *gin.Context) {
+	err := hdl.spectatorService.Revoke(request.Param("user_id"), request.Param("game_id"), request.Param("token"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.Status(http.StatusNoContent)
+}
+
+func (hdl *SpectatorHandler) Get(request *gin.Context) {
+	view, err := hdl.spectatorService.Get(request.Param("token"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+
+	request.JSON(http.StatusOK, view)
+}
+
+// Events streams the game shared by the link as server-sent events: the current state first and then every new one,
+// until the client goes away or the link is revoked
+func (hdl *SpectatorHandler) Events(request *gin.Context) {
+	view, updates, unsubscribe, err := hdl.spectatorService.Subscribe(request.Param("token"))
+	if err != nil {
+		log.Error(errors.String(err))
+		request.AbortWithStatusJSON(apierror.New(err))
+		return
+	}
+	defer unsubscribe()
+
+	request.SSEvent(gameEvent, view)
+	request.Stream(func(w io.Writer) bool {
+		select {
+		case view, ok := <-updates:
+			if !ok {
+				return false
+			}
+
+			request.SSEvent(gameEvent, view)
+			return true
+		case <-request.Request.Context().Done():
+			return false
+		}
+	})
+}
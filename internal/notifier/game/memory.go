@@ -0,0 +1,81 @@
+package game
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"sync"
+)
+
+// memory delivers the states of the games to the subscribers of the same process. Every subscriber only keeps the
+// latest state, so a slow subscriber never blocks the publisher and never receives an outdated state after a newer one
+type memory struct {
+	mutex       sync.Mutex
+	subscribers map[string]map[chan domain.Game]bool
+	keys        map[string]map[chan domain.Game]func()
+}
+
+func NewMemory() *memory {
+	return &memory{subscribers: map[string]map[chan domain.Game]bool{}, keys: map[string]map[chan domain.Game]func(){}}
+}
+
+func (ntf *memory) Publish(game domain.Game) {
+	ntf.mutex.Lock()
+	defer ntf.mutex.Unlock()
+
+	for subscriber := range ntf.subscribers[game.ID] {
+		select {
+		case <-subscriber:
+		default:
+		}
+
+		subscriber <- game
+	}
+}
+
+func (ntf *memory) Subscribe(gameID string, key string) (<-chan domain.Game, func()) {
+	ntf.mutex.Lock()
+	defer ntf.mutex.Unlock()
+
+	subscriber := make(chan domain.Game, 1)
+	if ntf.subscribers[gameID] == nil {
+		ntf.subscribers[gameID] = map[chan domain.Game]bool{}
+	}
+	ntf.subscribers[gameID][subscriber] = true
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			ntf.mutex.Lock()
+			defer ntf.mutex.Unlock()
+
+			delete(ntf.subscribers[gameID], subscriber)
+			if len(ntf.subscribers[gameID]) == 0 {
+				delete(ntf.subscribers, gameID)
+			}
+			delete(ntf.keys[key], subscriber)
+			if len(ntf.keys[key]) == 0 {
+				delete(ntf.keys, key)
+			}
+			close(subscriber)
+		})
+	}
+
+	if ntf.keys[key] == nil {
+		ntf.keys[key] = map[chan domain.Game]func(){}
+	}
+	ntf.keys[key][subscriber] = unsubscribe
+
+	return subscriber, unsubscribe
+}
+
+func (ntf *memory) Cancel(key string) {
+	ntf.mutex.Lock()
+	var unsubscribes []func()
+	for _, unsubscribe := range ntf.keys[key] {
+		unsubscribes = append(unsubscribes, unsubscribe)
+	}
+	ntf.mutex.Unlock()
+
+	for _, unsubscribe := range unsubscribes {
+		unsubscribe()
+	}
+}
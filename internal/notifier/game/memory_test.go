@@ -0,0 +1,57 @@
+package game_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/notifier/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMemory_Publish(t *testing.T) {
+	// Setup
+	notifier := game.NewMemory()
+	first, unsubscribeFirst := notifier.Subscribe("g1", "t1")
+	second, unsubscribeSecond := notifier.Subscribe("g1", "t2")
+	other, unsubscribeOther := notifier.Subscribe("g2", "t3")
+	defer unsubscribeFirst()
+	defer unsubscribeOther()
+
+	// Execute
+	notifier.Publish(domain.Game{ID: "g1", Version: 1})
+	unsubscribeSecond()
+	notifier.Publish(domain.Game{ID: "g1", Version: 2})
+
+	// Verify
+	assert.Equal(t, domain.Game{ID: "g1", Version: 2}, <-first)
+	assert.Len(t, first, 0)
+
+	update, ok := <-second
+	assert.Equal(t, domain.Game{ID: "g1", Version: 1}, update)
+	assert.True(t, ok)
+	_, ok = <-second
+	assert.False(t, ok)
+
+	assert.Len(t, other, 0)
+}
+
+func TestMemory_Cancel(t *testing.T) {
+	// Setup
+	notifier := game.NewMemory()
+	first, unsubscribeFirst := notifier.Subscribe("g1", "t1")
+	second, _ := notifier.Subscribe("g2", "t1")
+	other, unsubscribeOther := notifier.Subscribe("g1", "t2")
+	defer unsubscribeOther()
+
+	// Execute
+	notifier.Cancel("t1")
+	notifier.Publish(domain.Game{ID: "g1", Version: 1})
+	unsubscribeFirst()
+
+	// Verify
+	_, ok := <-first
+	assert.False(t, ok)
+	_, ok = <-second
+	assert.False(t, ok)
+
+	assert.Equal(t, domain.Game{ID: "g1", Version: 1}, <-other)
+}
@@ -0,0 +1,58 @@
+package match
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"sync"
+)
+
+// memory delivers the states of the matches to the subscribers of the same process. Every subscriber only keeps the
+// latest state, so a slow subscriber never blocks the publisher and never receives an outdated state after a newer one
+type memory struct {
+	mutex       sync.Mutex
+	subscribers map[string]map[chan domain.Match]bool
+}
+
+func NewMemory() *memory {
+	return &memory{subscribers: map[string]map[chan domain.Match]bool{}}
+}
+
+func (ntf *memory) Publish(match domain.Match) {
+	ntf.mutex.Lock()
+	defer ntf.mutex.Unlock()
+
+	for subscriber := range ntf.subscribers[match.ID] {
+		select {
+		case <-subscriber:
+		default:
+		}
+
+		subscriber <- match
+	}
+}
+
+func (ntf *memory) Subscribe(matchID string) (<-chan domain.Match, func()) {
+	ntf.mutex.Lock()
+	defer ntf.mutex.Unlock()
+
+	subscriber := make(chan domain.Match, 1)
+	if ntf.subscribers[matchID] == nil {
+		ntf.subscribers[matchID] = map[chan domain.Match]bool{}
+	}
+	ntf.subscribers[matchID][subscriber] = true
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			ntf.mutex.Lock()
+			defer ntf.mutex.Unlock()
+
+			delete(ntf.subscribers[matchID], subscriber)
+			if len(ntf.subscribers[matchID]) == 0 {
+				delete(ntf.subscribers, matchID)
+			}
+			close(subscriber)
+		})
+	}
+
+	return subscriber, unsubscribe
+}
@@ -0,0 +1,35 @@
+package match_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/notifier/match"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMemory_Publish(t *testing.T) {
+	// Setup
+	notifier := match.NewMemory()
+	first, unsubscribeFirst := notifier.Subscribe("m1")
+	second, unsubscribeSecond := notifier.Subscribe("m1")
+	other, unsubscribeOther := notifier.Subscribe("m2")
+	defer unsubscribeFirst()
+	defer unsubscribeOther()
+
+	// Execute
+	notifier.Publish(domain.Match{ID: "m1", Version: 1})
+	unsubscribeSecond()
+	notifier.Publish(domain.Match{ID: "m1", Version: 2})
+
+	// Verify
+	assert.Equal(t, domain.Match{ID: "m1", Version: 2}, <-first)
+	assert.Len(t, first, 0)
+
+	update, ok := <-second
+	assert.Equal(t, domain.Match{ID: "m1", Version: 1}, update)
+	assert.True(t, ok)
+	_, ok = <-second
+	assert.False(t, ok)
+
+	assert.Len(t, other, 0)
+}
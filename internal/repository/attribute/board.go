@@ -0,0 +1,58 @@
+package attribute
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+)
+
+// Board is a board stored in dynamo db as a binary attribute encoded with domain.Board.Encode. Boards stored before
+// as base64 strings or as lists of lists of cells are still read
+type Board domain.Board
+
+func (board Board) MarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	if board == nil {
+		av.NULL = aws.Bool(true)
+		return nil
+	}
+
+	encoded, err := domain.Board(board).Encode()
+	if err != nil {
+		return err
+	}
+
+	av.B = encoded
+
+	return nil
+}
+
+func (board *Board) UnmarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	switch {
+	case av.B != nil:
+		decoded, err := domain.DecodeBoard(av.B)
+		if err != nil {
+			return err
+		}
+
+		*board = Board(decoded)
+	case av.S != nil:
+		decoded, err := domain.DecodeBoardString(*av.S)
+		if err != nil {
+			return err
+		}
+
+		*board = Board(decoded)
+	case av.L != nil:
+		var legacy domain.Board
+		if err := dynamodbattribute.Unmarshal(av, &legacy); err != nil {
+			return err
+		}
+
+		*board = Board(legacy)
+	default:
+		*board = nil
+	}
+
+	return nil
+}
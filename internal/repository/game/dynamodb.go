@@ -1,7 +1,9 @@
 package game
 
 import (
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/matiasvarela/errors"
@@ -10,6 +12,22 @@ import (
 	"github.com/matiasvarela/minesweeper-API/pkg/dynamodbiface"
 )
 
+// IDIndexName is the global secondary index of the table keyed by the id of the games, projecting only the keys
+const IDIndexName = "id-index"
+
+// PuzzleIndexName is the global secondary index of the table keyed by the puzzle of the games, projecting all their
+// attributes. Only the puzzle games have the key, so they are the only ones in it
+const PuzzleIndexName = "puzzle_id-index"
+
+// ExpiryIndexName is the global secondary index of the table keyed by the state of the games and sorted by when they
+// expire, projecting all their attributes. Only the ongoing games with time limit have the sort key, so they are the
+// only ones in it
+const ExpiryIndexName = "expires_at-index"
+
+// MaxEncodedSize is the most bytes the boards and mask of a game can take once encoded. DynamoDB limits items to 400 KB,
+// and what is not taken by them is left for the rest of the attributes of the game: its log, participants and settings
+const MaxEncodedSize = 350 * 1024
+
 type awsDynamoDB struct {
 	tableName string
 	client    dynamodbiface.DynamoDB
@@ -24,13 +42,51 @@ type GameKey struct {
 	UserID string `json:"user_id"`
 }
 
+// Get reads the game consistently, so a game that has just been saved is never read in a previous version. It is first
+// read as owned by the given user. Otherwise the user can only be a participant, so the owner is found through the index
+// by id, which is eventually consistent, and the game is read again with its key
 func (db *awsDynamoDB) Get(userID string, gameID string) (*domain.Game, error) {
+	game, err := db.getItem(userID, gameID)
+	if err != nil || game != nil {
+		return game, err
+	}
+
+	keys, err := db.client.Query(&dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		IndexName:              aws.String(IDIndexName),
+		KeyConditionExpression: aws.String("id = :id"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":id": {S: aws.String(gameID)},
+		},
+	})
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at querying item key from dynamo db")
+	}
+
+	if len(keys.Items) == 0 {
+		return nil, nil
+	}
+
+	key := GameKey{}
+	if err := dynamodbattribute.UnmarshalMap(keys.Items[0], &key); err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at unmarshalling item key")
+	}
+
+	return db.getItem(key.UserID, key.ID)
+}
+
+// getItem reads consistently the game with the given key
+func (db *awsDynamoDB) getItem(userID string, gameID string) (*domain.Game, error) {
 	key, err := dynamodbattribute.MarshalMap(GameKey{ID: gameID, UserID: userID})
 	if err != nil {
-		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at generating dynamo db key")
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at creating item key")
 	}
 
-	result, err := db.client.GetItem(&dynamodb.GetItemInput{Key: key, TableName: aws.String(db.tableName)})
+	result, err := db.client.GetItem(&dynamodb.GetItemInput{
+		Key:            key,
+		TableName:      aws.String(db.tableName),
+		ConsistentRead: aws.Bool(true),
+	})
 	if err != nil {
 		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting item from dynamo db")
 	}
@@ -39,59 +95,105 @@ func (db *awsDynamoDB) Get(userID string, gameID string) (*domain.Game, error) {
 		return nil, nil
 	}
 
-	game := domain.Game{}
-	if err := dynamodbattribute.UnmarshalMap(result.Item, &game); err != nil {
+	game, err := unmarshalGame(result.Item)
+	if err != nil {
 		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at unmarshalling item")
 	}
 
 	return &game, nil
 }
 
+// GetAll retrieves the games owned by the given user, following the pages of results
 func (db *awsDynamoDB) GetAll(userID string) ([]domain.Game, error) {
-	var queryInput = &dynamodb.QueryInput{
-		TableName: aws.String(db.tableName),
-		KeyConditions: map[string]*dynamodb.Condition{
-			"user_id": {
-				ComparisonOperator: aws.String("EQ"),
-				AttributeValueList: []*dynamodb.AttributeValue{
-					{
-						S: aws.String(userID),
-					},
-				},
-			},
+	return db.query(&dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		KeyConditionExpression: aws.String("user_id = :user_id"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":user_id": {S: aws.String(userID)},
 		},
-	}
+	})
+}
 
-	resp, err := db.client.Query(queryInput)
-	if err != nil {
-		return nil, err
-	}
+// GetAllTimed retrieves the ongoing games with time limit through the index by expiry
+func (db *awsDynamoDB) GetAllTimed() ([]domain.Game, error) {
+	return db.query(&dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		IndexName:              aws.String(ExpiryIndexName),
+		KeyConditionExpression: aws.String("#state = :state"),
+		ExpressionAttributeNames: map[string]*string{
+			"#state": aws.String("state"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":state": {S: aws.String(domain.GameStateOnGoing)},
+		},
+	})
+}
 
+// GetAllByPuzzle retrieves the games played on the given puzzle through the index by puzzle
+func (db *awsDynamoDB) GetAllByPuzzle(puzzleID string) ([]domain.Game, error) {
+	return db.query(&dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		IndexName:              aws.String(PuzzleIndexName),
+		KeyConditionExpression: aws.String("puzzle_id = :puzzle_id"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":puzzle_id": {S: aws.String(puzzleID)},
+		},
+	})
+}
+
+// query retrieves all the games that match the given query, following the pages of results
+func (db *awsDynamoDB) query(queryInput *dynamodb.QueryInput) ([]domain.Game, error) {
 	games := []domain.Game{}
-	var game domain.Game
 
-	for _, item := range resp.Items {
-		game = domain.Game{}
-		if err := dynamodbattribute.UnmarshalMap(item, &game); err != nil {
-			return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at unmarshalling item")
+	for {
+		resp, err := db.client.Query(queryInput)
+		if err != nil {
+			return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at querying items from dynamo db")
 		}
 
-		games = append(games, game)
+		for _, item := range resp.Items {
+			game, err := unmarshalGame(item)
+			if err != nil {
+				return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at unmarshalling item")
+			}
+
+			games = append(games, game)
+		}
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		queryInput.ExclusiveStartKey = resp.LastEvaluatedKey
 	}
 
 	return games, nil
 }
 
+// Save puts the game. Versioned games are only put if the stored one has the previous version, or no version at all
 func (db *awsDynamoDB) Save(game domain.Game) error {
-	item, err := dynamodbattribute.MarshalMap(game)
+	item, err := marshalGame(game)
 	if err != nil {
 		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at creating item")
 	}
 
-	_, err = db.client.PutItem(&dynamodb.PutItemInput{
+	input := &dynamodb.PutItemInput{
 		Item:      item,
 		TableName: aws.String(db.tableName),
-	})
+	}
+
+	if game.Version > 0 {
+		input.ConditionExpression = aws.String("attribute_not_exists(version) OR version = :previous")
+		input.ExpressionAttributeValues = map[string]*dynamodb.AttributeValue{
+			":previous": {N: aws.String(fmt.Sprint(game.Version - 1))},
+		}
+	}
+
+	_, err = db.client.PutItem(input)
+
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return errors.New(apperrors.Conflict, err, "the game has been changed concurrently", "failed at saving item with a stale version")
+	}
 
 	if err != nil {
 		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving item")
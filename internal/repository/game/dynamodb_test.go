@@ -1,6 +1,8 @@
 package game_test
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/golang/mock/gomock"
@@ -10,7 +12,9 @@ import (
 	"github.com/matiasvarela/minesweeper-API/mock"
 	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
+	"time"
 )
 
 type dep struct {
@@ -25,7 +29,8 @@ func newDep(t *testing.T) dep {
 
 func TestAwsDynamoDB_Get(t *testing.T) {
 	type args struct {
-		id string
+		userID string
+		id     string
 	}
 
 	type want struct {
@@ -40,28 +45,314 @@ func TestAwsDynamoDB_Get(t *testing.T) {
 		mock func(dep, args)
 	}{
 		{
-			name: "get game successfully",
-			args: args{id: "xyz"},
-			want: want{result: &domain.Game{ID: "xyz"}},
+			name: "get game of its owner successfully with a consistent read",
+			args: args{userID: "111", id: "xyz"},
+			want: want{result: &domain.Game{ID: "xyz", UserID: "111"}},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz", UserID: "111"})
+				dep.client.EXPECT().GetItem(gomock.Any()).DoAndReturn(func(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+					assert.Equal(t, "111", aws.StringValue(input.Key["user_id"].S))
+					assert.Equal(t, "xyz", aws.StringValue(input.Key["id"].S))
+					assert.True(t, aws.BoolValue(input.ConsistentRead))
+					return &dynamodb.GetItemOutput{Item: r}, nil
+				})
+			},
+		},
+		{
+			name: "get game of one of its participants through the index by id",
+			args: args{userID: "222", id: "xyz"},
+			want: want{result: &domain.Game{ID: "xyz", UserID: "111", Participants: []string{"222"}}},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz", UserID: "111", Participants: []string{"222"}})
+				gomock.InOrder(
+					dep.client.EXPECT().GetItem(gomock.Any()).DoAndReturn(func(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+						assert.Equal(t, "222", aws.StringValue(input.Key["user_id"].S))
+						return &dynamodb.GetItemOutput{}, nil
+					}),
+					expectKey(dep, "111", arg.id),
+					dep.client.EXPECT().GetItem(gomock.Any()).DoAndReturn(func(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+						assert.Equal(t, "111", aws.StringValue(input.Key["user_id"].S))
+						assert.Equal(t, "xyz", aws.StringValue(input.Key["id"].S))
+						assert.True(t, aws.BoolValue(input.ConsistentRead))
+						return &dynamodb.GetItemOutput{Item: r}, nil
+					}),
+				)
+			},
+		},
+		{
+			name: "get game with board successfully",
+			args: args{userID: "111", id: "xyz"},
+			want: want{result: &domain.Game{ID: "xyz", Board: domain.Board{{"E", "b"}, {"e", "X"}}, History: []domain.Snapshot{{Board: domain.Board{{"e", "b"}, {"e", "e"}}}}}},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz"})
+				board, _ := domain.Board{{"E", "b"}, {"e", "X"}}.Encode()
+				snapshot, _ := domain.Board{{"e", "b"}, {"e", "e"}}.Encode()
+				r["board"] = &dynamodb.AttributeValue{B: board}
+				r["history"] = &dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{{M: map[string]*dynamodb.AttributeValue{
+					"board": {B: snapshot},
+				}}}}
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: r}, nil)
+			},
+		},
+		{
+			name: "get game with board stored as a base64 string successfully",
+			args: args{userID: "111", id: "xyz"},
+			want: want{result: &domain.Game{ID: "xyz", Board: domain.Board{{"E", "b", "e"}, {"X", "D", "B"}}}},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz"})
+				r["board"] = &dynamodb.AttributeValue{S: aws.String("AAAAAgAAAANDEHg=")}
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: r}, nil)
+			},
+		},
+		{
+			name: "get game with board stored as a list of cells successfully",
+			args: args{userID: "111", id: "xyz"},
+			want: want{result: &domain.Game{ID: "xyz", Board: domain.Board{{"E", "b"}, {"e", "X"}}}},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz", Board: domain.Board{{"E", "b"}, {"e", "X"}}})
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: r}, nil)
+			},
+		},
+		{
+			name: "fail at unmarshalling an invalid board",
+			args: args{userID: "111", id: "xyz"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at unmarshalling item")},
 			mock: func(dep dep, arg args) {
 				r, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz"})
+				r["board"] = &dynamodb.AttributeValue{S: aws.String("%%%")}
 				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: r}, nil)
 			},
 		},
 		{
 			name: "fail at getting game from dynamodb",
-			args: args{id: "xyz"},
+			args: args{userID: "111", id: "xyz"},
 			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting item from dynamo db")},
 			mock: func(dep dep, arg args) {
 				dep.client.EXPECT().GetItem(gomock.Any()).Return(nil, apperrors.Internal)
 			},
 		},
+		{
+			name: "fail at querying the key of the game from dynamodb",
+			args: args{userID: "222", id: "xyz"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at querying item key from dynamo db")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				dep.client.EXPECT().Query(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
 		{
 			name: "game not found",
-			args: args{id: "xyz"},
+			args: args{userID: "222", id: "xyz"},
 			want: want{result: nil, err: nil},
 			mock: func(dep dep, arg args) {
-				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: nil}, nil)
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				dep.client.EXPECT().Query(gomock.Any()).Return(&dynamodb.QueryOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := game.NewDynamoDB("Games", dep.client)
+			tt.mock(dep, tt.args)
+			result, err := repo.Get(tt.args.userID, tt.args.id)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+// expectKey mocks the query of the key of the game through the index by id
+func expectKey(dep dep, userID string, id string) *gomock.Call {
+	key, _ := dynamodbattribute.MarshalMap(game.GameKey{ID: id, UserID: userID})
+	return dep.client.EXPECT().Query(gomock.Any()).DoAndReturn(func(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+		if aws.StringValue(input.IndexName) != game.IDIndexName || aws.StringValue(input.ExpressionAttributeValues[":id"].S) != id {
+			return nil, apperrors.Internal
+		}
+
+		return &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{key}}, nil
+	})
+}
+
+func TestAwsDynamoDB_GetAll(t *testing.T) {
+	type args struct {
+		userID string
+	}
+
+	type want struct {
+		result []domain.Game
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args)
+	}{
+		{
+			name: "get games of a user successfully following the pages of results",
+			args: args{userID: "111"},
+			want: want{result: []domain.Game{{ID: "xyz", UserID: "111"}, {ID: "abc", UserID: "111"}}},
+			mock: func(dep dep, arg args) {
+				r1, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz", UserID: "111"})
+				r2, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "abc", UserID: "111"})
+				key, _ := dynamodbattribute.MarshalMap(game.GameKey{ID: "xyz", UserID: "111"})
+				gomock.InOrder(
+					dep.client.EXPECT().Query(gomock.Any()).DoAndReturn(func(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+						assert.Nil(t, input.IndexName)
+						assert.Equal(t, arg.userID, aws.StringValue(input.ExpressionAttributeValues[":user_id"].S))
+						assert.Nil(t, input.ExclusiveStartKey)
+						return &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{r1}, LastEvaluatedKey: key}, nil
+					}),
+					dep.client.EXPECT().Query(gomock.Any()).DoAndReturn(func(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, key, input.ExclusiveStartKey)
+						return &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{r2}}, nil
+					}),
+				)
+			},
+		},
+		{
+			name: "fail at querying games from dynamodb",
+			args: args{userID: "111"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at querying items from dynamo db")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().Query(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := game.NewDynamoDB("Games", dep.client)
+			tt.mock(dep, tt.args)
+			result, err := repo.GetAll(tt.args.userID)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestAwsDynamoDB_GetAllTimed(t *testing.T) {
+	type want struct {
+		result []domain.Game
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(dep)
+	}{
+		{
+			name: "get timed games successfully through the index by expiry",
+			want: want{result: []domain.Game{{ID: "xyz"}, {ID: "abc"}}},
+			mock: func(dep dep) {
+				r1, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz"})
+				r2, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "abc"})
+				key, _ := dynamodbattribute.MarshalMap(game.GameKey{ID: "xyz", UserID: "111"})
+				gomock.InOrder(
+					dep.client.EXPECT().Query(gomock.Any()).DoAndReturn(func(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, game.ExpiryIndexName, aws.StringValue(input.IndexName))
+						assert.Equal(t, domain.GameStateOnGoing, aws.StringValue(input.ExpressionAttributeValues[":state"].S))
+						assert.Nil(t, input.ExclusiveStartKey)
+						return &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{r1}, LastEvaluatedKey: key}, nil
+					}),
+					dep.client.EXPECT().Query(gomock.Any()).DoAndReturn(func(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, key, input.ExclusiveStartKey)
+						return &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{r2}}, nil
+					}),
+				)
+			},
+		},
+		{
+			name: "fail at querying games from dynamodb",
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at querying items from dynamo db")},
+			mock: func(dep dep) {
+				dep.client.EXPECT().Query(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := game.NewDynamoDB("Games", dep.client)
+			tt.mock(dep)
+			result, err := repo.GetAllTimed()
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestAwsDynamoDB_GetAllByPuzzle(t *testing.T) {
+	type args struct {
+		puzzleID string
+	}
+
+	type want struct {
+		result []domain.Game
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args)
+	}{
+		{
+			name: "get games of a puzzle successfully through the index by puzzle",
+			args: args{puzzleID: "abc"},
+			want: want{result: []domain.Game{
+				{ID: "xyz", Settings: domain.GameSettings{PuzzleID: "abc"}},
+				{ID: "uvw", Settings: domain.GameSettings{PuzzleID: "abc"}},
+			}},
+			mock: func(dep dep, arg args) {
+				r1, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "xyz", Settings: domain.GameSettings{PuzzleID: "abc"}})
+				r2, _ := dynamodbattribute.MarshalMap(domain.Game{ID: "uvw", Settings: domain.GameSettings{PuzzleID: "abc"}})
+				key, _ := dynamodbattribute.MarshalMap(game.GameKey{ID: "xyz", UserID: "111"})
+				gomock.InOrder(
+					dep.client.EXPECT().Query(gomock.Any()).DoAndReturn(func(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, game.PuzzleIndexName, aws.StringValue(input.IndexName))
+						assert.Equal(t, arg.puzzleID, aws.StringValue(input.ExpressionAttributeValues[":puzzle_id"].S))
+						assert.Nil(t, input.ExclusiveStartKey)
+						return &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{r1}, LastEvaluatedKey: key}, nil
+					}),
+					dep.client.EXPECT().Query(gomock.Any()).DoAndReturn(func(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, key, input.ExclusiveStartKey)
+						return &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{r2}}, nil
+					}),
+				)
+			},
+		},
+		{
+			name: "fail at querying games from dynamodb",
+			args: args{puzzleID: "abc"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at querying items from dynamo db")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().Query(gomock.Any()).Return(nil, apperrors.Internal)
 			},
 		},
 	}
@@ -71,9 +362,9 @@ func TestAwsDynamoDB_Get(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			dep := newDep(t)
-			repo := game.NewDynamoDB(dep.client)
+			repo := game.NewDynamoDB("Games", dep.client)
 			tt.mock(dep, tt.args)
-			result, err := repo.Get(tt.args.id)
+			result, err := repo.GetAllByPuzzle(tt.args.puzzleID)
 
 			assert.Equal(t, tt.want.result, result)
 			if err != nil && tt.want.err != nil {
@@ -107,6 +398,100 @@ func TestAwsDynamoDB_Save(t *testing.T) {
 				dep.client.EXPECT().PutItem(gomock.Any()).Return(nil, nil)
 			},
 		},
+		{
+			name: "save game with the board encoded successfully",
+			args: args{game: domain.Game{ID: "xyz", Board: domain.Board{{"E", "b"}, {"e", "X"}}}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					board, _ := arg.game.Board.Encode()
+					assert.Equal(t, board, input.Item["board"].B)
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "save puzzle game with the puzzle copied out of the settings for the index by puzzle",
+			args: args{game: domain.Game{ID: "xyz", Settings: domain.GameSettings{PuzzleID: "abc"}}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.Equal(t, "abc", aws.StringValue(input.Item["puzzle_id"].S))
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "save game that is not from a puzzle out of the index by puzzle",
+			args: args{game: domain.Game{ID: "xyz"}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.NotContains(t, input.Item, "puzzle_id")
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "save ongoing game with time limit with when it expires for the index by expiry",
+			args: args{game: domain.Game{ID: "xyz", State: domain.GameStateOnGoing, StartedAt: time.Unix(1000, 0), Settings: domain.GameSettings{TimeLimit: 60}}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.Equal(t, "1060", aws.StringValue(input.Item["expires_at"].N))
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "save finished game with time limit out of the index by expiry",
+			args: args{game: domain.Game{ID: "xyz", State: domain.GameStateLost, StartedAt: time.Unix(1000, 0), Settings: domain.GameSettings{TimeLimit: 60}}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.NotContains(t, input.Item, "expires_at")
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "save ongoing game without time limit out of the index by expiry",
+			args: args{game: domain.Game{ID: "xyz", State: domain.GameStateOnGoing, StartedAt: time.Unix(1000, 0)}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.NotContains(t, input.Item, "expires_at")
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "save versioned game only if the stored one has the previous version",
+			args: args{game: domain.Game{ID: "xyz", Participants: []string{"222"}, Version: 3}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.Equal(t, "attribute_not_exists(version) OR version = :previous", aws.StringValue(input.ConditionExpression))
+					assert.Equal(t, "2", aws.StringValue(input.ExpressionAttributeValues[":previous"].N))
+					assert.Equal(t, "3", aws.StringValue(input.Item["version"].N))
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "fail at save a game changed concurrently",
+			args: args{game: domain.Game{ID: "xyz", Participants: []string{"222"}, Version: 3}},
+			want: want{err: errors.New(apperrors.Conflict, nil, "the game has been changed concurrently", "")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).Return(nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "conditional request failed", nil))
+			},
+		},
+		{
+			name: "fail at save a game with a cell of an unknown kind",
+			args: args{game: domain.Game{ID: "xyz", Board: domain.Board{{"E", "?"}}}},
+			want: want{err: errors.New(apperrors.Internal, nil, "an internal error has occurred", "failed at creating item")},
+			mock: func(dep dep, arg args) {},
+		},
 		{
 			name: "fail at save the game into dynamodb",
 			args: args{game: domain.Game{ID:"xyz"}},
@@ -122,7 +507,7 @@ func TestAwsDynamoDB_Save(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			dep := newDep(t)
-			repo := game.NewDynamoDB(dep.client)
+			repo := game.NewDynamoDB("Games", dep.client)
 			tt.mock(dep, tt.args)
 			err := repo.Save(tt.args.game)
 
@@ -133,3 +518,83 @@ func TestAwsDynamoDB_Save(t *testing.T) {
 		})
 	}
 }
+
+func TestAwsDynamoDB_Save_BiggestGame(t *testing.T) {
+	const (
+		id        = "0123456789abcdef0123456789abcdef0123"
+		rows      = 234
+		columns   = 234
+		maskCells = 74
+	)
+
+	alternating := func() domain.Board {
+		board := domain.NewEmptyBoard(rows, columns)
+		for row := range board {
+			for column := range board[row] {
+				board[row][column] = domain.EmptyCellCovered
+				if (row+column)%2 == 0 {
+					board[row][column] = domain.EmptyCellRevealed
+				}
+			}
+		}
+		return board
+	}
+
+	settings := domain.GameSettings{Rows: rows, Columns: columns, BombsNumber: 1, Practice: true, PuzzleID: id}
+	for row := 0; row < rows; row++ {
+		settings.Mask.Layout = append(settings.Mask.Layout, strings.Repeat(string(domain.MaskLayoutEnabled), columns))
+	}
+	for i := 0; i < maskCells; i++ {
+		settings.Mask.Cells = append(settings.Mask.Cells, domain.NewPosition(rows-1, columns-1-i))
+	}
+
+	biggest := domain.Game{ID: id, UserID: id, SourceGameID: id, MatchID: id, Board: alternating(), Settings: settings}
+	for i := 0; i < 10; i++ {
+		biggest.Participants = append(biggest.Participants, id)
+		biggest.History = append(biggest.History, domain.Snapshot{Board: alternating()})
+	}
+	for i := 0; i < 100; i++ {
+		biggest.Log = append(biggest.Log, domain.ActionRecord{UserID: id, Action: domain.Action{Type: "reveal", Coordinates: domain.NewOffsetCoordinates(rows - 1, columns - 1)}, Move: 1000000})
+	}
+
+	assert.True(t, settings.EncodedSize(len(biggest.History)) <= game.MaxEncodedSize)
+
+	dep := newDep(t)
+	dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+		size := 0
+		for name, value := range input.Item {
+			size += len(name) + attributeSize(value)
+		}
+
+		assert.True(t, size < 400*1024, "the item takes %d bytes", size)
+		return nil, nil
+	})
+
+	assert.Nil(t, game.NewDynamoDB("Games", dep.client).Save(biggest))
+}
+
+// attributeSize approximates the bytes dynamo db counts for an attribute value against the size limit of the items
+func attributeSize(value *dynamodb.AttributeValue) int {
+	switch {
+	case value.S != nil:
+		return len(aws.StringValue(value.S))
+	case value.N != nil:
+		return len(aws.StringValue(value.N))/2 + 2
+	case value.B != nil:
+		return len(value.B)
+	case value.M != nil:
+		size := 3
+		for name, v := range value.M {
+			size += len(name) + attributeSize(v) + 1
+		}
+		return size
+	case value.L != nil:
+		size := 3
+		for _, v := range value.L {
+			size += attributeSize(v) + 1
+		}
+		return size
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/repository/attribute"
+)
+
+// gameItem is the representation of a game stored in dynamo db. It is the same as the domain one except for the
+// boards, which are stored compactly encoded, the id of the puzzle, which is copied out of the settings for the
+// puzzle games so they can be found through the index by puzzle, and the unix time when the game expires, which only
+// the ongoing games with time limit have so they can be found through the index by expiry
+type gameItem struct {
+	domain.Game
+	Board     attribute.Board `json:"board"`
+	History   []snapshotItem  `json:"history,omitempty"`
+	PuzzleID  string          `json:"puzzle_id,omitempty"`
+	ExpiresAt int64           `json:"expires_at,omitempty"`
+}
+
+type snapshotItem struct {
+	domain.Snapshot
+	Board attribute.Board `json:"board"`
+}
+
+func newGameItem(game domain.Game) gameItem {
+	item := gameItem{Game: game, Board: attribute.Board(game.Board), PuzzleID: game.Settings.PuzzleID}
+	if deadline, ok := game.Deadline(); ok {
+		item.ExpiresAt = deadline.Unix()
+	}
+	for _, snapshot := range game.History {
+		item.History = append(item.History, snapshotItem{Snapshot: snapshot, Board: attribute.Board(snapshot.Board)})
+	}
+
+	return item
+}
+
+func (item gameItem) toDomain() domain.Game {
+	game := item.Game
+	game.Board = domain.Board(item.Board)
+	game.History = nil
+
+	for _, snapshot := range item.History {
+		s := snapshot.Snapshot
+		s.Board = domain.Board(snapshot.Board)
+		game.History = append(game.History, s)
+	}
+
+	return game
+}
+
+func marshalGame(game domain.Game) (map[string]*dynamodb.AttributeValue, error) {
+	return dynamodbattribute.MarshalMap(newGameItem(game))
+}
+
+func unmarshalGame(av map[string]*dynamodb.AttributeValue) (domain.Game, error) {
+	item := gameItem{}
+	if err := dynamodbattribute.UnmarshalMap(av, &item); err != nil {
+		return domain.Game{}, err
+	}
+
+	return item.toDomain(), nil
+}
@@ -0,0 +1,80 @@
+package match
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/dynamodbiface"
+)
+
+type awsDynamoDB struct {
+	tableName string
+	client    dynamodbiface.DynamoDB
+}
+
+func NewDynamoDB(tableName string, client dynamodbiface.DynamoDB) *awsDynamoDB {
+	return &awsDynamoDB{client: client, tableName: tableName}
+}
+
+type MatchKey struct {
+	ID string `json:"id"`
+}
+
+func (db *awsDynamoDB) Get(matchID string) (*domain.Match, error) {
+	key, err := dynamodbattribute.MarshalMap(MatchKey{ID: matchID})
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at generating dynamo db key")
+	}
+
+	result, err := db.client.GetItem(&dynamodb.GetItemInput{
+		Key:            key,
+		TableName:      aws.String(db.tableName),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting item from dynamo db")
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	match := domain.Match{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &match); err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at unmarshalling item")
+	}
+
+	return &match, nil
+}
+
+// Save puts the match only if the stored one has the previous version, or if there is none for the first version
+func (db *awsDynamoDB) Save(match domain.Match) error {
+	item, err := dynamodbattribute.MarshalMap(match)
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at creating item")
+	}
+
+	_, err = db.client.PutItem(&dynamodb.PutItemInput{
+		Item:                item,
+		TableName:           aws.String(db.tableName),
+		ConditionExpression: aws.String("attribute_not_exists(id) OR version = :previous"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":previous": {N: aws.String(fmt.Sprint(match.Version - 1))},
+		},
+	})
+
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return errors.New(apperrors.Conflict, err, "the match has been changed concurrently", "failed at saving item with a stale version")
+	}
+
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving item")
+	}
+
+	return nil
+}
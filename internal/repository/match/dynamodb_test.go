@@ -0,0 +1,161 @@
+package match_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/repository/match"
+	"github.com/matiasvarela/minesweeper-API/mock"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type dep struct {
+	client *mock.MockDynamoDB
+}
+
+func newDep(t *testing.T) dep {
+	return dep{
+		client: mock.NewMockDynamoDB(gomock.NewController(t)),
+	}
+}
+
+func TestAwsDynamoDB_Get(t *testing.T) {
+	type args struct {
+		id string
+	}
+
+	type want struct {
+		result *domain.Match
+		err    error
+	}
+
+	stored := domain.Match{
+		ID:           "m1",
+		Participants: []domain.MatchParticipant{{UserID: "111", GameID: "g1", State: domain.GameStateWon}},
+		State:        domain.MatchStateFinished,
+		WinnerID:     "111",
+		Version:      3,
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args)
+	}{
+		{
+			name: "get match successfully",
+			args: args{id: "m1"},
+			want: want{result: &stored},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(stored)
+				dep.client.EXPECT().GetItem(gomock.Any()).DoAndReturn(func(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+					assert.True(t, aws.BoolValue(input.ConsistentRead))
+					return &dynamodb.GetItemOutput{Item: r}, nil
+				})
+			},
+		},
+		{
+			name: "fail at getting match from dynamodb",
+			args: args{id: "m1"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting item from dynamo db")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+		{
+			name: "match not found",
+			args: args{id: "m1"},
+			want: want{result: nil, err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: nil}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := match.NewDynamoDB("Matches", dep.client)
+			tt.mock(dep, tt.args)
+			result, err := repo.Get(tt.args.id)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestAwsDynamoDB_Save(t *testing.T) {
+	type args struct {
+		match domain.Match
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args)
+	}{
+		{
+			name: "save match on its previous version successfully",
+			args: args{match: domain.Match{ID: "m1", Version: 4}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.Equal(t, "attribute_not_exists(id) OR version = :previous", aws.StringValue(input.ConditionExpression))
+					assert.Equal(t, "3", aws.StringValue(input.ExpressionAttributeValues[":previous"].N))
+					assert.Equal(t, "4", aws.StringValue(input.Item["version"].N))
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "fail at save a match changed concurrently",
+			args: args{match: domain.Match{ID: "m1", Version: 4}},
+			want: want{err: errors.New(apperrors.Conflict, apperrors.Internal, "the match has been changed concurrently", "failed at saving item with a stale version")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).Return(nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "the conditional request failed", nil))
+			},
+		},
+		{
+			name: "fail at save the match into dynamodb",
+			args: args{match: domain.Match{ID: "m1", Version: 1}},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving item")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := match.NewDynamoDB("Matches", dep.client)
+			tt.mock(dep, tt.args)
+			err := repo.Save(tt.args.match)
+
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
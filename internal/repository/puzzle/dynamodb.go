@@ -0,0 +1,75 @@
+package puzzle
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/repository/attribute"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/dynamodbiface"
+)
+
+type awsDynamoDB struct {
+	tableName string
+	client    dynamodbiface.DynamoDB
+}
+
+func NewDynamoDB(tableName string, client dynamodbiface.DynamoDB) *awsDynamoDB {
+	return &awsDynamoDB{client: client, tableName: tableName}
+}
+
+type PuzzleKey struct {
+	ID string `json:"id"`
+}
+
+// puzzleItem is the representation of a puzzle stored in dynamo db, with its board compactly encoded
+type puzzleItem struct {
+	domain.Puzzle
+	Board attribute.Board `json:"board"`
+}
+
+func (db *awsDynamoDB) Get(puzzleID string) (*domain.Puzzle, error) {
+	key, err := dynamodbattribute.MarshalMap(PuzzleKey{ID: puzzleID})
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at generating dynamo db key")
+	}
+
+	result, err := db.client.GetItem(&dynamodb.GetItemInput{Key: key, TableName: aws.String(db.tableName)})
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting item from dynamo db")
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	item := puzzleItem{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at unmarshalling item")
+	}
+
+	puzzle := item.Puzzle
+	puzzle.Board = domain.Board(item.Board)
+
+	return &puzzle, nil
+}
+
+func (db *awsDynamoDB) Save(puzzle domain.Puzzle) error {
+	item, err := dynamodbattribute.MarshalMap(puzzleItem{Puzzle: puzzle, Board: attribute.Board(puzzle.Board)})
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at creating item")
+	}
+
+	_, err = db.client.PutItem(&dynamodb.PutItemInput{
+		Item:      item,
+		TableName: aws.String(db.tableName),
+	})
+
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving item")
+	}
+
+	return nil
+}
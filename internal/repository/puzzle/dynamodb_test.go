@@ -0,0 +1,152 @@
+package puzzle_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/repository/puzzle"
+	"github.com/matiasvarela/minesweeper-API/mock"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type dep struct {
+	client *mock.MockDynamoDB
+}
+
+func newDep(t *testing.T) dep {
+	return dep{
+		client: mock.NewMockDynamoDB(gomock.NewController(t)),
+	}
+}
+
+func TestAwsDynamoDB_Get(t *testing.T) {
+	type args struct {
+		id string
+	}
+
+	type want struct {
+		result *domain.Puzzle
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args)
+	}{
+		{
+			name: "get puzzle successfully",
+			args: args{id: "abc"},
+			want: want{result: &domain.Puzzle{ID: "abc", Rows: 2, Columns: 2, BombsNumber: 1, Board: domain.Board{{"b", "e"}, {"e", "D"}}}},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(domain.Puzzle{ID: "abc", Rows: 2, Columns: 2, BombsNumber: 1})
+				board, _ := domain.Board{{"b", "e"}, {"e", "D"}}.Encode()
+				r["board"] = &dynamodb.AttributeValue{B: board}
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: r}, nil)
+			},
+		},
+		{
+			name: "fail at unmarshalling an invalid board",
+			args: args{id: "abc"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at unmarshalling item")},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(domain.Puzzle{ID: "abc"})
+				r["board"] = &dynamodb.AttributeValue{S: aws.String("%%%")}
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: r}, nil)
+			},
+		},
+		{
+			name: "fail at getting puzzle from dynamodb",
+			args: args{id: "abc"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting item from dynamo db")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+		{
+			name: "puzzle not found",
+			args: args{id: "abc"},
+			want: want{result: nil, err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: nil}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := puzzle.NewDynamoDB("Puzzles", dep.client)
+			tt.mock(dep, tt.args)
+			result, err := repo.Get(tt.args.id)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestAwsDynamoDB_Save(t *testing.T) {
+	type args struct {
+		puzzle domain.Puzzle
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args)
+	}{
+		{
+			name: "save puzzle with the board encoded successfully",
+			args: args{puzzle: domain.Puzzle{ID: "abc", Board: domain.Board{{"b", "e"}, {"e", "D"}}}},
+			want: want{err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					board, _ := arg.puzzle.Board.Encode()
+					assert.Equal(t, board, input.Item["board"].B)
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "fail at save the puzzle into dynamodb",
+			args: args{puzzle: domain.Puzzle{ID: "abc"}},
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving item")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().PutItem(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := puzzle.NewDynamoDB("Puzzles", dep.client)
+			tt.mock(dep, tt.args)
+			err := repo.Save(tt.args.puzzle)
+
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package sharelink
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/dynamodbiface"
+)
+
+type awsDynamoDB struct {
+	tableName string
+	client    dynamodbiface.DynamoDB
+}
+
+func NewDynamoDB(tableName string, client dynamodbiface.DynamoDB) *awsDynamoDB {
+	return &awsDynamoDB{client: client, tableName: tableName}
+}
+
+type ShareLinkKey struct {
+	Token string `json:"token"`
+}
+
+func (db *awsDynamoDB) Get(token string) (*domain.ShareLink, error) {
+	key, err := dynamodbattribute.MarshalMap(ShareLinkKey{Token: token})
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at generating dynamo db key")
+	}
+
+	result, err := db.client.GetItem(&dynamodb.GetItemInput{
+		Key:            key,
+		TableName:      aws.String(db.tableName),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at getting item from dynamo db")
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	link := domain.ShareLink{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &link); err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at unmarshalling item")
+	}
+
+	return &link, nil
+}
+
+func (db *awsDynamoDB) Save(link domain.ShareLink) error {
+	item, err := dynamodbattribute.MarshalMap(link)
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at creating item")
+	}
+
+	_, err = db.client.PutItem(&dynamodb.PutItemInput{
+		Item:      item,
+		TableName: aws.String(db.tableName),
+	})
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at saving item")
+	}
+
+	return nil
+}
+
+func (db *awsDynamoDB) Delete(token string) error {
+	key, err := dynamodbattribute.MarshalMap(ShareLinkKey{Token: token})
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at generating dynamo db key")
+	}
+
+	_, err = db.client.DeleteItem(&dynamodb.DeleteItemInput{
+		Key:       key,
+		TableName: aws.String(db.tableName),
+	})
+	if err != nil {
+		return errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at deleting item")
+	}
+
+	return nil
+}
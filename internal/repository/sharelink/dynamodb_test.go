@@ -0,0 +1,185 @@
+package sharelink_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/internal/repository/sharelink"
+	"github.com/matiasvarela/minesweeper-API/mock"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type dep struct {
+	client *mock.MockDynamoDB
+}
+
+func newDep(t *testing.T) dep {
+	return dep{
+		client: mock.NewMockDynamoDB(gomock.NewController(t)),
+	}
+}
+
+var stored = domain.ShareLink{Token: "t1", GameID: "xyz", UserID: "111", CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+func TestAwsDynamoDB_Get(t *testing.T) {
+	type args struct {
+		token string
+	}
+
+	type want struct {
+		result *domain.ShareLink
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(dep, args)
+	}{
+		{
+			name: "get share link successfully",
+			args: args{token: "t1"},
+			want: want{result: &stored},
+			mock: func(dep dep, arg args) {
+				r, _ := dynamodbattribute.MarshalMap(stored)
+				dep.client.EXPECT().GetItem(gomock.Any()).DoAndReturn(func(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+					assert.Equal(t, arg.token, aws.StringValue(input.Key["token"].S))
+					return &dynamodb.GetItemOutput{Item: r}, nil
+				})
+			},
+		},
+		{
+			name: "fail at getting share link from dynamodb",
+			args: args{token: "t1"},
+			want: want{result: nil, err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at getting item from dynamo db")},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+		{
+			name: "share link not found",
+			args: args{token: "t1"},
+			want: want{result: nil, err: nil},
+			mock: func(dep dep, arg args) {
+				dep.client.EXPECT().GetItem(gomock.Any()).Return(&dynamodb.GetItemOutput{Item: nil}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := sharelink.NewDynamoDB("ShareLinks", dep.client)
+			tt.mock(dep, tt.args)
+			result, err := repo.Get(tt.args.token)
+
+			assert.Equal(t, tt.want.result, result)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestAwsDynamoDB_Save(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(dep)
+	}{
+		{
+			name: "save share link successfully",
+			want: want{err: nil},
+			mock: func(dep dep) {
+				dep.client.EXPECT().PutItem(gomock.Any()).DoAndReturn(func(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+					assert.Equal(t, "t1", aws.StringValue(input.Item["token"].S))
+					assert.Equal(t, "xyz", aws.StringValue(input.Item["game_id"].S))
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "fail at save the share link into dynamodb",
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at saving item")},
+			mock: func(dep dep) {
+				dep.client.EXPECT().PutItem(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := sharelink.NewDynamoDB("ShareLinks", dep.client)
+			tt.mock(dep)
+			err := repo.Save(stored)
+
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestAwsDynamoDB_Delete(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(dep)
+	}{
+		{
+			name: "delete share link successfully",
+			want: want{err: nil},
+			mock: func(dep dep) {
+				dep.client.EXPECT().DeleteItem(gomock.Any()).DoAndReturn(func(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+					assert.Equal(t, "t1", aws.StringValue(input.Key["token"].S))
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "fail at delete the share link from dynamodb",
+			want: want{err: errors.New(apperrors.Internal, apperrors.Internal, "an internal error has occurred", "failed at deleting item")},
+			mock: func(dep dep) {
+				dep.client.EXPECT().DeleteItem(gomock.Any()).Return(nil, apperrors.Internal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDep(t)
+			repo := sharelink.NewDynamoDB("ShareLinks", dep.client)
+			tt.mock(dep)
+			err := repo.Delete("t1")
+
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
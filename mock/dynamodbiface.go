@@ -48,6 +48,21 @@ func (mr *MockDynamoDBMockRecorder) Query(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockDynamoDB)(nil).Query), arg0)
 }
 
+// Scan mocks base method
+func (m *MockDynamoDB) Scan(arg0 *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", arg0)
+	ret0, _ := ret[0].(*dynamodb.ScanOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scan indicates an expected call of Scan
+func (mr *MockDynamoDBMockRecorder) Scan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockDynamoDB)(nil).Scan), arg0)
+}
+
 // GetItem mocks base method
 func (m *MockDynamoDB) GetItem(arg0 *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
 	m.ctrl.T.Helper()
@@ -77,3 +92,18 @@ func (mr *MockDynamoDBMockRecorder) PutItem(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutItem", reflect.TypeOf((*MockDynamoDB)(nil).PutItem), arg0)
 }
+
+// DeleteItem mocks base method
+func (m *MockDynamoDB) DeleteItem(arg0 *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", arg0)
+	ret0, _ := ret[0].(*dynamodb.DeleteItemOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteItem indicates an expected call of DeleteItem
+func (mr *MockDynamoDBMockRecorder) DeleteItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockDynamoDB)(nil).DeleteItem), arg0)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notifier.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	reflect "reflect"
+)
+
+// MockMatchNotifier is a mock of MatchNotifier interface
+type MockMatchNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchNotifierMockRecorder
+}
+
+// MockMatchNotifierMockRecorder is the mock recorder for MockMatchNotifier
+type MockMatchNotifierMockRecorder struct {
+	mock *MockMatchNotifier
+}
+
+// NewMockMatchNotifier creates a new mock instance
+func NewMockMatchNotifier(ctrl *gomock.Controller) *MockMatchNotifier {
+	mock := &MockMatchNotifier{ctrl: ctrl}
+	mock.recorder = &MockMatchNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMatchNotifier) EXPECT() *MockMatchNotifierMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method
+func (m *MockMatchNotifier) Publish(match domain.Match) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", match)
+}
+
+// Publish indicates an expected call of Publish
+func (mr *MockMatchNotifierMockRecorder) Publish(match interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockMatchNotifier)(nil).Publish), match)
+}
+
+// Subscribe mocks base method
+func (m *MockMatchNotifier) Subscribe(matchID string) (<-chan domain.Match, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", matchID)
+	ret0, _ := ret[0].(<-chan domain.Match)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockMatchNotifierMockRecorder) Subscribe(matchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockMatchNotifier)(nil).Subscribe), matchID)
+}
+
+// MockGameNotifier is a mock of GameNotifier interface
+type MockGameNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameNotifierMockRecorder
+}
+
+// MockGameNotifierMockRecorder is the mock recorder for MockGameNotifier
+type MockGameNotifierMockRecorder struct {
+	mock *MockGameNotifier
+}
+
+// NewMockGameNotifier creates a new mock instance
+func NewMockGameNotifier(ctrl *gomock.Controller) *MockGameNotifier {
+	mock := &MockGameNotifier{ctrl: ctrl}
+	mock.recorder = &MockGameNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGameNotifier) EXPECT() *MockGameNotifierMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method
+func (m *MockGameNotifier) Publish(game domain.Game) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", game)
+}
+
+// Publish indicates an expected call of Publish
+func (mr *MockGameNotifierMockRecorder) Publish(game interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockGameNotifier)(nil).Publish), game)
+}
+
+// Subscribe mocks base method
+func (m *MockGameNotifier) Subscribe(gameID, key string) (<-chan domain.Game, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", gameID, key)
+	ret0, _ := ret[0].(<-chan domain.Game)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockGameNotifierMockRecorder) Subscribe(gameID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockGameNotifier)(nil).Subscribe), gameID, key)
+}
+
+// Cancel mocks base method
+func (m *MockGameNotifier) Cancel(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Cancel", key)
+}
+
+// Cancel indicates an expected call of Cancel
+func (mr *MockGameNotifierMockRecorder) Cancel(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockGameNotifier)(nil).Cancel), key)
+}
@@ -58,6 +58,20 @@ func (mr *MockRandomMockRecorder) GenerateN(n interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateN", reflect.TypeOf((*MockRandom)(nil).GenerateN), n)
 }
 
+// Intn mocks base method
+func (m *MockRandom) Intn(n int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Intn", n)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Intn indicates an expected call of Intn
+func (mr *MockRandomMockRecorder) Intn(n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Intn", reflect.TypeOf((*MockRandom)(nil).Intn), n)
+}
+
 // GenerateID mocks base method
 func (m *MockRandom) GenerateID() string {
 	m.ctrl.T.Helper()
@@ -63,6 +63,36 @@ func (mr *MockGameRepositoryMockRecorder) GetAll(userID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGameRepository)(nil).GetAll), userID)
 }
 
+// GetAllTimed mocks base method
+func (m *MockGameRepository) GetAllTimed() ([]domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTimed")
+	ret0, _ := ret[0].([]domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTimed indicates an expected call of GetAllTimed
+func (mr *MockGameRepositoryMockRecorder) GetAllTimed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTimed", reflect.TypeOf((*MockGameRepository)(nil).GetAllTimed))
+}
+
+// GetAllByPuzzle mocks base method
+func (m *MockGameRepository) GetAllByPuzzle(puzzleID string) ([]domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByPuzzle", puzzleID)
+	ret0, _ := ret[0].([]domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByPuzzle indicates an expected call of GetAllByPuzzle
+func (mr *MockGameRepositoryMockRecorder) GetAllByPuzzle(puzzleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByPuzzle", reflect.TypeOf((*MockGameRepository)(nil).GetAllByPuzzle), puzzleID)
+}
+
 // Save mocks base method
 func (m *MockGameRepository) Save(game domain.Game) error {
 	m.ctrl.T.Helper()
@@ -76,3 +106,173 @@ func (mr *MockGameRepositoryMockRecorder) Save(game interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockGameRepository)(nil).Save), game)
 }
+
+// MockPuzzleRepository is a mock of PuzzleRepository interface
+type MockPuzzleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPuzzleRepositoryMockRecorder
+}
+
+// MockPuzzleRepositoryMockRecorder is the mock recorder for MockPuzzleRepository
+type MockPuzzleRepositoryMockRecorder struct {
+	mock *MockPuzzleRepository
+}
+
+// NewMockPuzzleRepository creates a new mock instance
+func NewMockPuzzleRepository(ctrl *gomock.Controller) *MockPuzzleRepository {
+	mock := &MockPuzzleRepository{ctrl: ctrl}
+	mock.recorder = &MockPuzzleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPuzzleRepository) EXPECT() *MockPuzzleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockPuzzleRepository) Get(puzzleID string) (*domain.Puzzle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", puzzleID)
+	ret0, _ := ret[0].(*domain.Puzzle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockPuzzleRepositoryMockRecorder) Get(puzzleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPuzzleRepository)(nil).Get), puzzleID)
+}
+
+// Save mocks base method
+func (m *MockPuzzleRepository) Save(puzzle domain.Puzzle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", puzzle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockPuzzleRepositoryMockRecorder) Save(puzzle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPuzzleRepository)(nil).Save), puzzle)
+}
+
+// MockMatchRepository is a mock of MatchRepository interface
+type MockMatchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchRepositoryMockRecorder
+}
+
+// MockMatchRepositoryMockRecorder is the mock recorder for MockMatchRepository
+type MockMatchRepositoryMockRecorder struct {
+	mock *MockMatchRepository
+}
+
+// NewMockMatchRepository creates a new mock instance
+func NewMockMatchRepository(ctrl *gomock.Controller) *MockMatchRepository {
+	mock := &MockMatchRepository{ctrl: ctrl}
+	mock.recorder = &MockMatchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMatchRepository) EXPECT() *MockMatchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockMatchRepository) Get(matchID string) (*domain.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", matchID)
+	ret0, _ := ret[0].(*domain.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockMatchRepositoryMockRecorder) Get(matchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMatchRepository)(nil).Get), matchID)
+}
+
+// Save mocks base method
+func (m *MockMatchRepository) Save(match domain.Match) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", match)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockMatchRepositoryMockRecorder) Save(match interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMatchRepository)(nil).Save), match)
+}
+
+// MockShareLinkRepository is a mock of ShareLinkRepository interface
+type MockShareLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareLinkRepositoryMockRecorder
+}
+
+// MockShareLinkRepositoryMockRecorder is the mock recorder for MockShareLinkRepository
+type MockShareLinkRepositoryMockRecorder struct {
+	mock *MockShareLinkRepository
+}
+
+// NewMockShareLinkRepository creates a new mock instance
+func NewMockShareLinkRepository(ctrl *gomock.Controller) *MockShareLinkRepository {
+	mock := &MockShareLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockShareLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockShareLinkRepository) EXPECT() *MockShareLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockShareLinkRepository) Get(token string) (*domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", token)
+	ret0, _ := ret[0].(*domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockShareLinkRepositoryMockRecorder) Get(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockShareLinkRepository)(nil).Get), token)
+}
+
+// Save mocks base method
+func (m *MockShareLinkRepository) Save(link domain.ShareLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockShareLinkRepositoryMockRecorder) Save(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockShareLinkRepository)(nil).Save), link)
+}
+
+// Delete mocks base method
+func (m *MockShareLinkRepository) Delete(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockShareLinkRepositoryMockRecorder) Delete(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShareLinkRepository)(nil).Delete), token)
+}
@@ -0,0 +1,469 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	reflect "reflect"
+)
+
+// MockGameService is a mock of GameService interface
+type MockGameService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameServiceMockRecorder
+}
+
+// MockGameServiceMockRecorder is the mock recorder for MockGameService
+type MockGameServiceMockRecorder struct {
+	mock *MockGameService
+}
+
+// NewMockGameService creates a new mock instance
+func NewMockGameService(ctrl *gomock.Controller) *MockGameService {
+	mock := &MockGameService{ctrl: ctrl}
+	mock.recorder = &MockGameServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGameService) EXPECT() *MockGameServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockGameService) Get(userID, gameID string) (domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, gameID)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockGameServiceMockRecorder) Get(userID, gameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGameService)(nil).Get), userID, gameID)
+}
+
+// GetAll mocks base method
+func (m *MockGameService) GetAll(userID string) ([]domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID)
+	ret0, _ := ret[0].([]domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockGameServiceMockRecorder) GetAll(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGameService)(nil).GetAll), userID)
+}
+
+// Create mocks base method
+func (m *MockGameService) Create(userID string, settings domain.GameSettings) (domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID, settings)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockGameServiceMockRecorder) Create(userID, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGameService)(nil).Create), userID, settings)
+}
+
+// MarkCell mocks base method
+func (m *MockGameService) MarkCell(userID, gameID string, coordinates domain.Coordinates) (domain.Game, []domain.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCell", userID, gameID, coordinates)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].([]domain.Position)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MarkCell indicates an expected call of MarkCell
+func (mr *MockGameServiceMockRecorder) MarkCell(userID, gameID, coordinates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCell", reflect.TypeOf((*MockGameService)(nil).MarkCell), userID, gameID, coordinates)
+}
+
+// RevealCell mocks base method
+func (m *MockGameService) RevealCell(userID, gameID string, coordinates domain.Coordinates) (domain.Game, []domain.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevealCell", userID, gameID, coordinates)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].([]domain.Position)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RevealCell indicates an expected call of RevealCell
+func (mr *MockGameServiceMockRecorder) RevealCell(userID, gameID, coordinates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevealCell", reflect.TypeOf((*MockGameService)(nil).RevealCell), userID, gameID, coordinates)
+}
+
+// Hint mocks base method
+func (m *MockGameService) Hint(userID, gameID string) (domain.Game, domain.Hint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hint", userID, gameID)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].(domain.Hint)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Hint indicates an expected call of Hint
+func (mr *MockGameServiceMockRecorder) Hint(userID, gameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hint", reflect.TypeOf((*MockGameService)(nil).Hint), userID, gameID)
+}
+
+// Analyze mocks base method
+func (m *MockGameService) Analyze(userID, gameID string) (domain.Analysis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analyze", userID, gameID)
+	ret0, _ := ret[0].(domain.Analysis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analyze indicates an expected call of Analyze
+func (mr *MockGameServiceMockRecorder) Analyze(userID, gameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analyze", reflect.TypeOf((*MockGameService)(nil).Analyze), userID, gameID)
+}
+
+// Undo mocks base method
+func (m *MockGameService) Undo(userID, gameID string) (domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", userID, gameID)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo
+func (mr *MockGameServiceMockRecorder) Undo(userID, gameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockGameService)(nil).Undo), userID, gameID)
+}
+
+// Retry mocks base method
+func (m *MockGameService) Retry(userID, gameID string) (domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", userID, gameID)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retry indicates an expected call of Retry
+func (mr *MockGameServiceMockRecorder) Retry(userID, gameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockGameService)(nil).Retry), userID, gameID)
+}
+
+// Act mocks base method
+func (m *MockGameService) Act(userID, gameID string, actions []domain.Action) (domain.Game, []domain.ActionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Act", userID, gameID, actions)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].([]domain.ActionResult)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Act indicates an expected call of Act
+func (mr *MockGameServiceMockRecorder) Act(userID, gameID, actions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Act", reflect.TypeOf((*MockGameService)(nil).Act), userID, gameID, actions)
+}
+
+// AddParticipant mocks base method
+func (m *MockGameService) AddParticipant(userID, gameID, participantID string) (domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddParticipant", userID, gameID, participantID)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddParticipant indicates an expected call of AddParticipant
+func (mr *MockGameServiceMockRecorder) AddParticipant(userID, gameID, participantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipant", reflect.TypeOf((*MockGameService)(nil).AddParticipant), userID, gameID, participantID)
+}
+
+// RemoveParticipant mocks base method
+func (m *MockGameService) RemoveParticipant(userID, gameID, participantID string) (domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveParticipant", userID, gameID, participantID)
+	ret0, _ := ret[0].(domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveParticipant indicates an expected call of RemoveParticipant
+func (mr *MockGameServiceMockRecorder) RemoveParticipant(userID, gameID, participantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveParticipant", reflect.TypeOf((*MockGameService)(nil).RemoveParticipant), userID, gameID, participantID)
+}
+
+// CreateMatchGames mocks base method
+func (m *MockGameService) CreateMatchGames(matchID string, userIDs []string, settings domain.GameSettings) ([]domain.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMatchGames", matchID, userIDs, settings)
+	ret0, _ := ret[0].([]domain.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMatchGames indicates an expected call of CreateMatchGames
+func (mr *MockGameServiceMockRecorder) CreateMatchGames(matchID, userIDs, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMatchGames", reflect.TypeOf((*MockGameService)(nil).CreateMatchGames), matchID, userIDs, settings)
+}
+
+// ExpireGames mocks base method
+func (m *MockGameService) ExpireGames() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireGames")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireGames indicates an expected call of ExpireGames
+func (mr *MockGameServiceMockRecorder) ExpireGames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireGames", reflect.TypeOf((*MockGameService)(nil).ExpireGames))
+}
+
+// MockPuzzleService is a mock of PuzzleService interface
+type MockPuzzleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPuzzleServiceMockRecorder
+}
+
+// MockPuzzleServiceMockRecorder is the mock recorder for MockPuzzleService
+type MockPuzzleServiceMockRecorder struct {
+	mock *MockPuzzleService
+}
+
+// NewMockPuzzleService creates a new mock instance
+func NewMockPuzzleService(ctrl *gomock.Controller) *MockPuzzleService {
+	mock := &MockPuzzleService{ctrl: ctrl}
+	mock.recorder = &MockPuzzleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPuzzleService) EXPECT() *MockPuzzleServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockPuzzleService) Get(puzzleID string) (domain.Puzzle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", puzzleID)
+	ret0, _ := ret[0].(domain.Puzzle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockPuzzleServiceMockRecorder) Get(puzzleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPuzzleService)(nil).Get), puzzleID)
+}
+
+// Create mocks base method
+func (m *MockPuzzleService) Create(design domain.PuzzleDesign) (domain.Puzzle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", design)
+	ret0, _ := ret[0].(domain.Puzzle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockPuzzleServiceMockRecorder) Create(design interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPuzzleService)(nil).Create), design)
+}
+
+// Leaderboard mocks base method
+func (m *MockPuzzleService) Leaderboard(puzzleID string) ([]domain.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leaderboard", puzzleID)
+	ret0, _ := ret[0].([]domain.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Leaderboard indicates an expected call of Leaderboard
+func (mr *MockPuzzleServiceMockRecorder) Leaderboard(puzzleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leaderboard", reflect.TypeOf((*MockPuzzleService)(nil).Leaderboard), puzzleID)
+}
+
+// MockMatchService is a mock of MatchService interface
+type MockMatchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchServiceMockRecorder
+}
+
+// MockMatchServiceMockRecorder is the mock recorder for MockMatchService
+type MockMatchServiceMockRecorder struct {
+	mock *MockMatchService
+}
+
+// NewMockMatchService creates a new mock instance
+func NewMockMatchService(ctrl *gomock.Controller) *MockMatchService {
+	mock := &MockMatchService{ctrl: ctrl}
+	mock.recorder = &MockMatchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMatchService) EXPECT() *MockMatchServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockMatchService) Get(userID, matchID string) (domain.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, matchID)
+	ret0, _ := ret[0].(domain.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockMatchServiceMockRecorder) Get(userID, matchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMatchService)(nil).Get), userID, matchID)
+}
+
+// Create mocks base method
+func (m *MockMatchService) Create(userID string, request domain.MatchRequest) (domain.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID, request)
+	ret0, _ := ret[0].(domain.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockMatchServiceMockRecorder) Create(userID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMatchService)(nil).Create), userID, request)
+}
+
+// Subscribe mocks base method
+func (m *MockMatchService) Subscribe(userID, matchID string) (domain.Match, <-chan domain.Match, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID, matchID)
+	ret0, _ := ret[0].(domain.Match)
+	ret1, _ := ret[1].(<-chan domain.Match)
+	ret2, _ := ret[2].(func())
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockMatchServiceMockRecorder) Subscribe(userID, matchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockMatchService)(nil).Subscribe), userID, matchID)
+}
+
+// MockSpectatorService is a mock of SpectatorService interface
+type MockSpectatorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpectatorServiceMockRecorder
+}
+
+// MockSpectatorServiceMockRecorder is the mock recorder for MockSpectatorService
+type MockSpectatorServiceMockRecorder struct {
+	mock *MockSpectatorService
+}
+
+// NewMockSpectatorService creates a new mock instance
+func NewMockSpectatorService(ctrl *gomock.Controller) *MockSpectatorService {
+	mock := &MockSpectatorService{ctrl: ctrl}
+	mock.recorder = &MockSpectatorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSpectatorService) EXPECT() *MockSpectatorServiceMockRecorder {
+	return m.recorder
+}
+
+// Share mocks base method
+func (m *MockSpectatorService) Share(userID, gameID string) (domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Share", userID, gameID)
+	ret0, _ := ret[0].(domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Share indicates an expected call of Share
+func (mr *MockSpectatorServiceMockRecorder) Share(userID, gameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockSpectatorService)(nil).Share), userID, gameID)
+}
+
+// Revoke mocks base method
+func (m *MockSpectatorService) Revoke(userID, gameID, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", userID, gameID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke
+func (mr *MockSpectatorServiceMockRecorder) Revoke(userID, gameID, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSpectatorService)(nil).Revoke), userID, gameID, token)
+}
+
+// Get mocks base method
+func (m *MockSpectatorService) Get(token string) (domain.SpectatorView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", token)
+	ret0, _ := ret[0].(domain.SpectatorView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockSpectatorServiceMockRecorder) Get(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSpectatorService)(nil).Get), token)
+}
+
+// Subscribe mocks base method
+func (m *MockSpectatorService) Subscribe(token string) (domain.SpectatorView, <-chan domain.SpectatorView, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", token)
+	ret0, _ := ret[0].(domain.SpectatorView)
+	ret1, _ := ret[1].(<-chan domain.SpectatorView)
+	ret2, _ := ret[2].(func())
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockSpectatorServiceMockRecorder) Subscribe(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSpectatorService)(nil).Subscribe), token)
+}
@@ -19,6 +19,12 @@ func New(err error) (int, ApiError) {
 		return http.StatusNotFound, ApiError{Status: http.StatusNotFound, Code: errors.Code(apperrors.NotFound), Message: err.Error(), Data: errors.Data(err)}
 	case errors.Code(apperrors.InvalidInput):
 		return http.StatusBadRequest, ApiError{Status: http.StatusBadRequest, Code: errors.Code(apperrors.InvalidInput), Message: err.Error(), Data: errors.Data(err)}
+	case errors.Code(apperrors.Conflict):
+		return http.StatusConflict, ApiError{Status: http.StatusConflict, Code: errors.Code(apperrors.Conflict), Message: err.Error(), Data: errors.Data(err)}
+	case errors.Code(apperrors.Unauthorized):
+		return http.StatusUnauthorized, ApiError{Status: http.StatusUnauthorized, Code: errors.Code(apperrors.Unauthorized), Message: err.Error(), Data: errors.Data(err)}
+	case errors.Code(apperrors.Forbidden):
+		return http.StatusForbidden, ApiError{Status: http.StatusForbidden, Code: errors.Code(apperrors.Forbidden), Message: err.Error(), Data: errors.Data(err)}
 	default:
 		return http.StatusInternalServerError, ApiError{Status: http.StatusInternalServerError, Code: errors.Code(apperrors.Internal), Message: err.Error(), Data: errors.Data(err)}
 	}
@@ -8,4 +8,7 @@ var (
 	Internal     = errors.Define("internal")
 	NotFound     = errors.Define("not_found")
 	InvalidInput = errors.Define("invalid_input")
+	Conflict     = errors.Define("conflict")
+	Unauthorized = errors.Define("unauthorized")
+	Forbidden    = errors.Define("forbidden")
 )
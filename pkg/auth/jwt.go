@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/clock"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+
+	defaultUserIDClaim = "sub"
+)
+
+// Verifier checks signed bearer tokens and returns the id of the user they have been issued to
+type Verifier interface {
+	Verify(token string) (string, error)
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+}
+
+// verifier checks JSON web tokens signed with HS256, with a shared secret, or RS256, with the public key of the issuer.
+// A token is only accepted with the algorithm of a key that has been given, so a token cannot choose how it is checked
+type verifier struct {
+	clock        clock.Clock
+	hmacSecret   []byte
+	rsaPublicKey *rsa.PublicKey
+	userIDClaim  string
+	issuer       string
+	audience     string
+}
+
+type Option func(v *verifier)
+
+func NewVerifier(clock clock.Clock, options ...Option) *verifier {
+	v := &verifier{clock: clock, userIDClaim: defaultUserIDClaim}
+	for _, option := range options {
+		option(v)
+	}
+
+	return v
+}
+
+// WithHMACSecret accepts the tokens signed with HS256 and the given secret
+func WithHMACSecret(secret []byte) Option {
+	return func(v *verifier) {
+		v.hmacSecret = secret
+	}
+}
+
+// WithRSAPublicKey accepts the tokens signed with RS256 and the private key of the given public key
+func WithRSAPublicKey(key *rsa.PublicKey) Option {
+	return func(v *verifier) {
+		v.rsaPublicKey = key
+	}
+}
+
+// WithUserIDClaim sets the claim that holds the id of the user, the subject by default
+func WithUserIDClaim(claim string) Option {
+	return func(v *verifier) {
+		v.userIDClaim = claim
+	}
+}
+
+// WithIssuer only accepts the tokens whose iss claim is the given issuer
+func WithIssuer(issuer string) Option {
+	return func(v *verifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience only accepts the tokens whose aud claim is or contains the given audience
+func WithAudience(audience string) Option {
+	return func(v *verifier) {
+		v.audience = audience
+	}
+}
+
+// ParseRSAPublicKey parses a PEM encoded RSA public key, either in PKIX or PKCS #1 form
+func ParseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(apperrors.Internal, nil, "an internal error has occurred", "failed at decoding pem block of the public key")
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New(apperrors.Internal, err, "an internal error has occurred", "failed at parsing public key")
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New(apperrors.Internal, nil, "an internal error has occurred", "the public key is not an rsa key")
+	}
+
+	return rsaKey, nil
+}
+
+// Verify checks the signature of the token, its expiration time, which is required, its not before time and, when they
+// have been given, its issuer and audience. It returns the id of the user the token has been issued to
+func (v *verifier) Verify(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New(apperrors.Unauthorized, nil, "invalid token", "the token has not three parts")
+	}
+
+	var h header
+	if err := decode(parts[0], &h); err != nil {
+		return "", errors.New(apperrors.Unauthorized, err, "invalid token", "failed at decoding token header")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.New(apperrors.Unauthorized, err, "invalid token", "failed at decoding token signature")
+	}
+
+	if err := v.verifySignature(h.Algorithm, parts[0]+"."+parts[1], signature); err != nil {
+		return "", errors.Wrap(err, err.Error())
+	}
+
+	claims := map[string]interface{}{}
+	if err := decode(parts[1], &claims); err != nil {
+		return "", errors.New(apperrors.Unauthorized, err, "invalid token", "failed at decoding token claims")
+	}
+
+	now := v.clock.Now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return "", errors.New(apperrors.Unauthorized, nil, "the token has no expiration", "")
+	}
+
+	if !now.Before(time.Unix(int64(exp), 0)) {
+		return "", errors.New(apperrors.Unauthorized, nil, "the token has expired", "")
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok && now.Before(time.Unix(int64(nbf), 0)) {
+		return "", errors.New(apperrors.Unauthorized, nil, "the token is not valid yet", "")
+	}
+
+	if issuer, _ := claims["iss"].(string); v.issuer != "" && issuer != v.issuer {
+		return "", errors.New(apperrors.Unauthorized, nil, "the token has not been issued by the expected issuer", "")
+	}
+
+	if v.audience != "" && !hasAudience(claims["aud"], v.audience) {
+		return "", errors.New(apperrors.Unauthorized, nil, "the token is not intended for this audience", "")
+	}
+
+	userID, _ := claims[v.userIDClaim].(string)
+	if userID == "" {
+		return "", errors.New(apperrors.Unauthorized, nil, "the token has no user id", "")
+	}
+
+	return userID, nil
+}
+
+// verifySignature checks the signature of the signed part of the token with the key of the given algorithm
+func (v *verifier) verifySignature(algorithm string, signed string, signature []byte) error {
+	switch {
+	case algorithm == AlgorithmHS256 && len(v.hmacSecret) > 0:
+		mac := hmac.New(sha256.New, v.hmacSecret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return errors.New(apperrors.Unauthorized, nil, "invalid token", "the token signature does not match")
+		}
+	case algorithm == AlgorithmRS256 && v.rsaPublicKey != nil:
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(v.rsaPublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New(apperrors.Unauthorized, err, "invalid token", "the token signature does not match")
+		}
+	default:
+		return errors.New(apperrors.Unauthorized, nil, "invalid token", "unsupported signing algorithm "+algorithm)
+	}
+
+	return nil
+}
+
+// hasAudience returns true if the aud claim, either a single audience or a list of them, has the given one
+func hasAudience(claim interface{}, audience string) bool {
+	switch aud := claim.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// decode decodes a base64url encoded json part of the token
+func decode(part string, value interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
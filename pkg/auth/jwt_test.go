@@ -0,0 +1,225 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/golang/mock/gomock"
+	"github.com/matiasvarela/errors"
+	"github.com/matiasvarela/minesweeper-API/mock"
+	"github.com/matiasvarela/minesweeper-API/pkg/apperrors"
+	"github.com/matiasvarela/minesweeper-API/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var (
+	mockedNow    = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockedSecret = []byte("secret")
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.Nil(t, err)
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.Nil(t, err)
+
+	expiresAt := mockedNow.Add(time.Minute).Unix()
+	claims := map[string]interface{}{"sub": "111", "exp": expiresAt}
+
+	type args struct {
+		token   string
+		options []auth.Option
+	}
+	type want struct {
+		userID string
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "verify HS256 token successfully",
+			args: args{token: signHS256(mockedSecret, claims), options: []auth.Option{auth.WithHMACSecret(mockedSecret)}},
+			want: want{userID: "111"},
+		},
+		{
+			name: "verify RS256 token successfully",
+			args: args{token: signRS256(rsaKey, claims), options: []auth.Option{auth.WithRSAPublicKey(&rsaKey.PublicKey)}},
+			want: want{userID: "111"},
+		},
+		{
+			name: "verify token with the user id in another claim",
+			args: args{
+				token:   signHS256(mockedSecret, map[string]interface{}{"sub": "abc", "user_id": "222", "exp": expiresAt}),
+				options: []auth.Option{auth.WithHMACSecret(mockedSecret), auth.WithUserIDClaim("user_id")},
+			},
+			want: want{userID: "222"},
+		},
+		{
+			name: "token signed with another secret",
+			args: args{token: signHS256([]byte("other"), claims), options: []auth.Option{auth.WithHMACSecret(mockedSecret)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "invalid token", "")},
+		},
+		{
+			name: "token signed with another rsa key",
+			args: args{token: signRS256(otherRSAKey, claims), options: []auth.Option{auth.WithRSAPublicKey(&rsaKey.PublicKey)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "invalid token", "")},
+		},
+		{
+			name: "token signed with an algorithm without key",
+			args: args{token: signHS256(mockedSecret, claims), options: []auth.Option{auth.WithRSAPublicKey(&rsaKey.PublicKey)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "invalid token", "")},
+		},
+		{
+			name: "unsigned token",
+			args: args{token: encode(map[string]interface{}{"alg": "none"}) + "." + encode(claims) + ".", options: []auth.Option{auth.WithHMACSecret(mockedSecret)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "invalid token", "")},
+		},
+		{
+			name: "malformed token",
+			args: args{token: "abc.def", options: []auth.Option{auth.WithHMACSecret(mockedSecret)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "invalid token", "")},
+		},
+		{
+			name: "expired token",
+			args: args{
+				token:   signHS256(mockedSecret, map[string]interface{}{"sub": "111", "exp": mockedNow.Unix()}),
+				options: []auth.Option{auth.WithHMACSecret(mockedSecret)},
+			},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token has expired", "")},
+		},
+		{
+			name: "token without expiration",
+			args: args{token: signHS256(mockedSecret, map[string]interface{}{"sub": "111"}), options: []auth.Option{auth.WithHMACSecret(mockedSecret)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token has no expiration", "")},
+		},
+		{
+			name: "token with an expiration that is not a number",
+			args: args{token: signHS256(mockedSecret, map[string]interface{}{"sub": "111", "exp": "tomorrow"}), options: []auth.Option{auth.WithHMACSecret(mockedSecret)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token has no expiration", "")},
+		},
+		{
+			name: "token not valid yet",
+			args: args{
+				token:   signHS256(mockedSecret, map[string]interface{}{"sub": "111", "exp": expiresAt, "nbf": mockedNow.Add(time.Minute).Unix()}),
+				options: []auth.Option{auth.WithHMACSecret(mockedSecret)},
+			},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token is not valid yet", "")},
+		},
+		{
+			name: "verify token from the expected issuer and for the expected audience successfully",
+			args: args{
+				token:   signHS256(mockedSecret, map[string]interface{}{"sub": "111", "exp": expiresAt, "iss": "https://auth.example.com", "aud": "minesweeper"}),
+				options: []auth.Option{auth.WithHMACSecret(mockedSecret), auth.WithIssuer("https://auth.example.com"), auth.WithAudience("minesweeper")},
+			},
+			want: want{userID: "111"},
+		},
+		{
+			name: "verify token with the expected audience among others successfully",
+			args: args{
+				token:   signHS256(mockedSecret, map[string]interface{}{"sub": "111", "exp": expiresAt, "aud": []string{"other", "minesweeper"}}),
+				options: []auth.Option{auth.WithHMACSecret(mockedSecret), auth.WithAudience("minesweeper")},
+			},
+			want: want{userID: "111"},
+		},
+		{
+			name: "token from another issuer",
+			args: args{
+				token:   signHS256(mockedSecret, map[string]interface{}{"sub": "111", "exp": expiresAt, "iss": "https://other.example.com"}),
+				options: []auth.Option{auth.WithHMACSecret(mockedSecret), auth.WithIssuer("https://auth.example.com")},
+			},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token has not been issued by the expected issuer", "")},
+		},
+		{
+			name: "token for another audience",
+			args: args{
+				token:   signHS256(mockedSecret, map[string]interface{}{"sub": "111", "exp": expiresAt, "aud": []string{"other"}}),
+				options: []auth.Option{auth.WithHMACSecret(mockedSecret), auth.WithAudience("minesweeper")},
+			},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token is not intended for this audience", "")},
+		},
+		{
+			name: "token without audience",
+			args: args{token: signHS256(mockedSecret, claims), options: []auth.Option{auth.WithHMACSecret(mockedSecret), auth.WithAudience("minesweeper")}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token is not intended for this audience", "")},
+		},
+		{
+			name: "token without user id",
+			args: args{token: signHS256(mockedSecret, map[string]interface{}{"exp": expiresAt}), options: []auth.Option{auth.WithHMACSecret(mockedSecret)}},
+			want: want{err: errors.New(apperrors.Unauthorized, nil, "the token has no user id", "")},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			clock := mock.NewMockClock(gomock.NewController(t))
+			clock.EXPECT().Now().Return(mockedNow).AnyTimes()
+
+			userID, err := auth.NewVerifier(clock, tt.args.options...).Verify(tt.args.token)
+
+			assert.Equal(t, tt.want.userID, userID)
+			if err != nil && tt.want.err != nil {
+				assert.Equal(t, tt.want.err.Error(), err.Error())
+			}
+			assert.Equal(t, errors.Code(tt.want.err), errors.Code(err))
+		})
+	}
+}
+
+func TestParseRSAPublicKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.Nil(t, err)
+
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.Nil(t, err)
+
+	for _, block := range []*pem.Block{
+		{Type: "PUBLIC KEY", Bytes: pkix},
+		{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)},
+	} {
+		parsed, err := auth.ParseRSAPublicKey(pem.EncodeToMemory(block))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &key.PublicKey, parsed)
+	}
+
+	_, err = auth.ParseRSAPublicKey([]byte("not a key"))
+	assert.Equal(t, errors.Code(apperrors.Internal), errors.Code(err))
+}
+
+func signHS256(secret []byte, claims map[string]interface{}) string {
+	signed := encode(map[string]interface{}{"alg": auth.AlgorithmHS256, "typ": "JWT"}) + "." + encode(claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(key *rsa.PrivateKey, claims map[string]interface{}) string {
+	signed := encode(map[string]interface{}{"alg": auth.AlgorithmRS256, "typ": "JWT"}) + "." + encode(claims)
+
+	digest := sha256.Sum256([]byte(signed))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encode(value interface{}) string {
+	data, _ := json.Marshal(value)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
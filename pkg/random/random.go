@@ -11,6 +11,7 @@ import (
 type Random interface {
 	Init()
 	GenerateN(n int) []int
+	Intn(n int) int
 	GenerateID() string
 }
 
@@ -28,6 +29,10 @@ func (r *random) GenerateN(n int) []int {
 	return rand.Perm(n)
 }
 
+func (r *random) Intn(n int) int {
+	return rand.Intn(n)
+}
+
 func (r *random) GenerateID() string {
 	return uuid.New().String()
 }
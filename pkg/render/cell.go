@@ -0,0 +1,60 @@
+package render
+
+import "github.com/matiasvarela/minesweeper-API/internal/core/domain"
+
+type kind int
+
+const (
+	covered kind = iota
+	revealed
+	flag
+	question
+	bomb
+	exploded
+	disabled
+)
+
+// classify returns how the cell in the given position must be drawn and, for revealed cells, the number of adjacent bombs
+func classify(board domain.Board, topology domain.Topology, pos domain.Position, showBombs bool) (kind, int) {
+	switch board.Get(pos) {
+	case domain.EmptyCellRevealed:
+		return revealed, board.CountNeighborBombs(topology, pos)
+	case domain.BombCellRevealed:
+		return exploded, 0
+	case domain.EmptyCellCoveredAndMarked, domain.BombCellCoveredAndMarked:
+		return flag, 0
+	case domain.EmptyCellCoveredAndQuestioned, domain.BombCellCoveredAndQuestioned:
+		return question, 0
+	case domain.DisabledCell:
+		return disabled, 0
+	case domain.BombCellCovered:
+		if showBombs {
+			return bomb, 0
+		}
+
+		return covered, 0
+	default:
+		return covered, 0
+	}
+}
+
+// imageSize returns the width and height in pixels of the image of a board where every cell is a square of cellSize
+// pixels. The odd rows of hexagonal boards are shifted half a cell to the right, so their images are half a cell wider
+func imageSize(topology domain.Topology, rows int, columns int, cellSize int) (int, int) {
+	width := columns * cellSize
+	if topology == domain.HexTopology && rows > 1 {
+		width += cellSize / 2
+	}
+
+	return width, rows * cellSize
+}
+
+// cellOrigin returns the top left corner in pixels of the cell in the given position
+func cellOrigin(topology domain.Topology, pos domain.Position, cellSize int) (int, int) {
+	x := pos.Column * cellSize
+	if topology == domain.HexTopology && pos.Row%2 == 1 {
+		x += cellSize / 2
+	}
+
+	return x, pos.Row * cellSize
+}
@@ -0,0 +1,133 @@
+package render
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// glyph is a 3x5 bitmap, one string per row
+type glyph [5]string
+
+// questionMark is the glyph drawn on the cells marked with a question
+var questionMark = glyph{"##.", "..#", ".#.", "...", ".#."}
+
+// digits is a 3x5 bitmap font for the numbers of the revealed cells
+var digits = [9]glyph{
+	{},
+	{".#.", "##.", ".#.", ".#.", "###"},
+	{"##.", "..#", ".#.", "#..", "###"},
+	{"##.", "..#", ".#.", "..#", "##."},
+	{"#.#", "#.#", "###", "..#", "..#"},
+	{"###", "#..", "##.", "..#", "##."},
+	{".##", "#..", "###", "#.#", "###"},
+	{"###", "..#", ".#.", ".#.", ".#."},
+	{"###", "#.#", "###", "#.#", "###"},
+}
+
+// PNG draws the board as a PNG image where every cell is a square of cellSize pixels.
+// Covered bombs are drawn as covered cells unless showBombs is true, and disabled cells are left blank.
+// In hexagonal boards the odd rows are shifted half a cell to the right, as their cells are laid out
+func PNG(w io.Writer, board domain.Board, topology domain.Topology, showBombs bool, cellSize int, theme Theme) error {
+	rows, columns := len(board), 0
+	if rows > 0 {
+		columns = len(board[0])
+	}
+
+	width, height := imageSize(topology, rows, columns, cellSize)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: theme.Background}, image.Point{}, draw.Src)
+
+	for row := range board {
+		for column := range board[row] {
+			pos := domain.NewPosition(row, column)
+			x, y := cellOrigin(topology, pos, cellSize)
+			k, count := classify(board, topology, pos, showBombs)
+			if k == disabled {
+				continue
+			}
+
+			background := theme.Covered
+			switch k {
+			case revealed:
+				background = theme.Revealed
+			case exploded:
+				background = theme.Exploded
+			}
+
+			fillRect(img, x+1, y+1, cellSize-2, cellSize-2, background)
+
+			switch k {
+			case revealed:
+				if count > 0 {
+					drawGlyph(img, x, y, cellSize, digits[count], theme.Numbers[count])
+				}
+			case bomb, exploded:
+				fillCircle(img, x+cellSize/2, y+cellSize/2, cellSize/4, theme.Bomb)
+			case flag:
+				fillTriangle(img, x+cellSize/3, y+cellSize/5, x+cellSize*4/5, y+cellSize*2/5, x+cellSize/3, y+cellSize*3/5, theme.Flag)
+				fillRect(img, x+cellSize/3, y+cellSize/5, max(1, cellSize/12), cellSize*3/5, theme.Bomb)
+			case question:
+				drawGlyph(img, x, y, cellSize, questionMark, theme.Flag)
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+func fillRect(img *image.RGBA, x int, y int, width int, height int, c color.RGBA) {
+	draw.Draw(img, image.Rect(x, y, x+width, y+height), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+func fillCircle(img *image.RGBA, cx int, cy int, r int, c color.RGBA) {
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			if dx*dx+dy*dy <= r*r {
+				img.SetRGBA(cx+dx, cy+dy, c)
+			}
+		}
+	}
+}
+
+// fillTriangle fills the triangle with the given vertices checking, for every pixel of its bounding box, on which side of each edge it is
+func fillTriangle(img *image.RGBA, x1 int, y1 int, x2 int, y2 int, x3 int, y3 int, c color.RGBA) {
+	edge := func(ax, ay, bx, by, px, py int) int {
+		return (bx-ax)*(py-ay) - (by-ay)*(px-ax)
+	}
+
+	for y := min(y1, min(y2, y3)); y <= max(y1, max(y2, y3)); y++ {
+		for x := min(x1, min(x2, x3)); x <= max(x1, max(x2, x3)); x++ {
+			e1, e2, e3 := edge(x1, y1, x2, y2, x, y), edge(x2, y2, x3, y3, x, y), edge(x3, y3, x1, y1, x, y)
+			if (e1 >= 0 && e2 >= 0 && e3 >= 0) || (e1 <= 0 && e2 <= 0 && e3 <= 0) {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+// drawGlyph draws the glyph centered in the cell, scaling the bitmap to the size of the cell
+func drawGlyph(img *image.RGBA, x int, y int, cellSize int, g glyph, c color.RGBA) {
+	scale := max(1, cellSize/8)
+	left := x + (cellSize-3*scale)/2
+	top := y + (cellSize-5*scale)/2
+
+	for row, line := range g {
+		for column, pixel := range line {
+			if pixel == '#' {
+				fillRect(img, left+column*scale, top+row*scale, scale, scale, c)
+			}
+		}
+	}
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
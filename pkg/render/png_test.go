@@ -0,0 +1,85 @@
+package render_test
+
+import (
+	"bytes"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/render"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestPNG(t *testing.T) {
+	type args struct {
+		board     domain.Board
+		topology  domain.Topology
+		showBombs bool
+		cellSize  int
+		theme     render.Theme
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		golden string
+	}{
+		{
+			name:   "ongoing game hides the bombs",
+			args:   args{board: mockBoard, topology: domain.StandardTopology, showBombs: false, cellSize: 24, theme: render.LightTheme},
+			golden: "png_ongoing.png",
+		},
+		{
+			name:   "finished game with dark theme and bigger cells",
+			args:   args{board: mockBoard, topology: domain.StandardTopology, showBombs: true, cellSize: 40, theme: render.DarkTheme},
+			golden: "png_finished_dark.png",
+		},
+		{
+			name:   "hexagonal board shifts the odd rows",
+			args:   args{board: mockHexBoard, topology: domain.HexTopology, showBombs: true, cellSize: 24, theme: render.LightTheme},
+			golden: "png_hex.png",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := render.PNG(buf, tt.args.board, tt.args.topology, tt.args.showBombs, tt.args.cellSize, tt.args.theme)
+			assert.Nil(t, err)
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// compare pixels instead of bytes since the compression may differ between versions of Go
+			assert.Equal(t, decode(t, want), decode(t, buf.Bytes()))
+		})
+	}
+}
+
+func decode(t *testing.T, data []byte) *image.RGBA {
+	t.Helper()
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	return rgba
+}
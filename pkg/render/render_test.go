@@ -0,0 +1,74 @@
+package render_test
+
+import (
+	"flag"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+var (
+	e = domain.EmptyCellCovered
+	X = domain.EmptyCellCoveredAndMarked
+	Q = domain.EmptyCellCoveredAndQuestioned
+	E = domain.EmptyCellRevealed
+
+	b = domain.BombCellCovered
+	Y = domain.BombCellCoveredAndMarked
+	R = domain.BombCellCoveredAndQuestioned
+	B = domain.BombCellRevealed
+
+	D = domain.DisabledCell
+)
+
+var mockBoard = domain.Board{
+	{E, E, E, E, E, e},
+	{E, E, E, b, E, e},
+	{E, E, E, E, X, Y},
+	{e, e, E, E, E, b},
+	{e, B, E, E, E, E},
+}
+
+// mockHexBoard is drawn with the hexagonal topology, where the odd rows are shifted half a cell to the right
+var mockHexBoard = domain.Board{
+	{e, e, E, E, E},
+	{E, b, E, E, E},
+	{E, E, E, E, E},
+	{E, E, E, E, b},
+}
+
+var mockQuestionedBoard = domain.Board{
+	{E, E, E, e},
+	{E, E, E, Q},
+	{E, E, E, R},
+	{e, X, E, e},
+}
+
+// assertGolden compares the result with the content of the golden file, or overwrites the file when running with -update
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, string(want), string(got))
+}
+
+var mockMaskedBoard = domain.Board{
+	{D, E, E, D},
+	{E, E, E, E},
+	{D, e, b, D},
+}
@@ -0,0 +1,33 @@
+package render
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"math"
+)
+
+// MaxPixels is the largest number of pixels an image of a board can have. A PNG takes 4 bytes per pixel while it is
+// drawn, so it keeps an image within 64 MB of memory
+const MaxPixels = 16 << 20
+
+// MaxCellSize returns the biggest cell size that keeps the image of a board of the given size and topology within MaxPixels
+func MaxCellSize(topology domain.Topology, rows int, columns int) int {
+	if rows <= 0 || columns <= 0 {
+		return math.MaxInt32
+	}
+
+	pixels := func(size int) int {
+		width, height := imageSize(topology, rows, columns, size)
+		return width * height
+	}
+
+	size := int(math.Sqrt(float64(MaxPixels) / float64(rows) / float64(columns)))
+	for pixels(size+1) <= MaxPixels {
+		size++
+	}
+
+	for size > 0 && pixels(size) > MaxPixels {
+		size--
+	}
+
+	return size
+}
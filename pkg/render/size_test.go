@@ -0,0 +1,51 @@
+package render_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/render"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMaxCellSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology domain.Topology
+		rows     int
+		columns  int
+		want     int
+	}{
+		{name: "small board", topology: domain.StandardTopology, rows: 10, columns: 10, want: 409},
+		{name: "board that fits exactly", topology: domain.StandardTopology, rows: 1024, columns: 1024, want: 4},
+		{name: "biggest board", topology: domain.StandardTopology, rows: 1000, columns: 1000, want: 4},
+		{name: "long board", topology: domain.StandardTopology, rows: 1, columns: 1000, want: 129},
+		{name: "board too big for any cell", topology: domain.StandardTopology, rows: 5000, columns: 5000, want: 0},
+		{name: "biggest hexagonal board", topology: domain.HexTopology, rows: 1000, columns: 1000, want: 4},
+		{name: "hexagonal board that fits exactly as a square one", topology: domain.HexTopology, rows: 1024, columns: 1024, want: 3},
+		{name: "empty board", topology: domain.StandardTopology, rows: 0, columns: 0, want: 2147483647},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			size := render.MaxCellSize(tt.topology, tt.rows, tt.columns)
+
+			assert.Equal(t, tt.want, size)
+			if tt.rows > 0 && tt.columns > 0 {
+				assert.True(t, pixels(tt.topology, tt.rows, tt.columns, size) <= render.MaxPixels)
+				assert.True(t, pixels(tt.topology, tt.rows, tt.columns, size+1) > render.MaxPixels)
+			}
+		})
+	}
+}
+
+// pixels counts the pixels of the image of a board, where the odd rows of hexagonal boards are shifted half a cell
+func pixels(topology domain.Topology, rows int, columns int, cellSize int) int {
+	width := columns * cellSize
+	if topology == domain.HexTopology && rows > 1 {
+		width += cellSize / 2
+	}
+
+	return width * rows * cellSize
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"fmt"
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"image/color"
+	"strings"
+)
+
+// SVG draws the board as an SVG image where every cell is a square of cellSize pixels.
+// Covered bombs are drawn as covered cells unless showBombs is true, and disabled cells are left blank.
+// In hexagonal boards the odd rows are shifted half a cell to the right, as their cells are laid out
+func SVG(board domain.Board, topology domain.Topology, showBombs bool, cellSize int, theme Theme) string {
+	rows, columns := len(board), 0
+	if rows > 0 {
+		columns = len(board[0])
+	}
+
+	var sb strings.Builder
+
+	width, height := imageSize(topology, rows, columns, cellSize)
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(theme.Background))
+
+	for row := range board {
+		for column := range board[row] {
+			pos := domain.NewPosition(row, column)
+			x, y := cellOrigin(topology, pos, cellSize)
+			k, count := classify(board, topology, pos, showBombs)
+			if k == disabled {
+				continue
+			}
+
+			background := theme.Covered
+			switch k {
+			case revealed:
+				background = theme.Revealed
+			case exploded:
+				background = theme.Exploded
+			}
+
+			fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x+1, y+1, cellSize-2, cellSize-2, hex(background))
+
+			switch k {
+			case revealed:
+				if count > 0 {
+					fmt.Fprintf(&sb, `<text x="%d" y="%d" font-family="monospace" font-size="%d" font-weight="bold" text-anchor="middle" dominant-baseline="central" fill="%s">%d</text>`+"\n",
+						x+cellSize/2, y+cellSize/2, cellSize*2/3, hex(theme.Numbers[count]), count)
+				}
+			case bomb, exploded:
+				fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", x+cellSize/2, y+cellSize/2, cellSize/4, hex(theme.Bomb))
+			case flag:
+				fmt.Fprintf(&sb, `<polygon points="%d,%d %d,%d %d,%d" fill="%s"/>`+"\n",
+					x+cellSize/3, y+cellSize/5, x+cellSize*4/5, y+cellSize*2/5, x+cellSize/3, y+cellSize*3/5, hex(theme.Flag))
+				fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					x+cellSize/3, y+cellSize/5, max(1, cellSize/12), cellSize*3/5, hex(theme.Bomb))
+			case question:
+				fmt.Fprintf(&sb, `<text x="%d" y="%d" font-family="monospace" font-size="%d" font-weight="bold" text-anchor="middle" dominant-baseline="central" fill="%s">?</text>`+"\n",
+					x+cellSize/2, y+cellSize/2, cellSize*2/3, hex(theme.Flag))
+			}
+		}
+	}
+
+	sb.WriteString("</svg>\n")
+
+	return sb.String()
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,59 @@
+package render_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/render"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	type args struct {
+		board     domain.Board
+		topology  domain.Topology
+		showBombs bool
+		cellSize  int
+		theme     render.Theme
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		golden string
+	}{
+		{
+			name:   "ongoing game hides the bombs",
+			args:   args{board: mockBoard, topology: domain.StandardTopology, showBombs: false, cellSize: 24, theme: render.LightTheme},
+			golden: "svg_ongoing.golden",
+		},
+		{
+			name:   "finished game with dark theme and bigger cells",
+			args:   args{board: mockBoard, topology: domain.StandardTopology, showBombs: true, cellSize: 40, theme: render.DarkTheme},
+			golden: "svg_finished_dark.golden",
+		},
+		{
+			name:   "cells marked with a question",
+			args:   args{board: mockQuestionedBoard, topology: domain.StandardTopology, showBombs: false, cellSize: 24, theme: render.LightTheme},
+			golden: "svg_questions.golden",
+		},
+		{
+			name:   "disabled cells are left blank",
+			args:   args{board: mockMaskedBoard, topology: domain.StandardTopology, showBombs: false, cellSize: 24, theme: render.LightTheme},
+			golden: "svg_masked.golden",
+		},
+		{
+			name:   "hexagonal board shifts the odd rows",
+			args:   args{board: mockHexBoard, topology: domain.HexTopology, showBombs: true, cellSize: 24, theme: render.LightTheme},
+			golden: "svg_hex.golden",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := render.SVG(tt.args.board, tt.args.topology, tt.args.showBombs, tt.args.cellSize, tt.args.theme)
+
+			assertGolden(t, tt.golden, []byte(got))
+		})
+	}
+}
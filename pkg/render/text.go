@@ -0,0 +1,130 @@
+package render
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"strconv"
+	"strings"
+)
+
+// Charset is the set of symbols used to draw the cells of a board as text
+type Charset struct {
+	Covered  string
+	Flag     string
+	Question string
+	Bomb     string
+	Exploded string
+	Empty    string
+	Disabled string
+}
+
+var (
+	ASCII   = Charset{Covered: "#", Flag: "F", Question: "?", Bomb: "*", Exploded: "!", Empty: ".", Disabled: " "}
+	Unicode = Charset{Covered: "■", Flag: "⚑", Question: "?", Bomb: "✱", Exploded: "✹", Empty: "·", Disabled: " "}
+)
+
+// Text draws the board as a grid with row and column headers. Revealed cells show the number of adjacent bombs in the given topology.
+// Covered bombs are drawn as covered cells unless showBombs is true, so the board of an ongoing game can be rendered safely,
+// and disabled cells are left blank.
+// In hexagonal boards the odd rows are shifted half a cell to the right, as their cells are laid out, and the headers give
+// axial coordinates instead: every row is labeled with its r and the q of its first cell, which grows by one to the right
+func Text(board domain.Board, topology domain.Topology, showBombs bool, charset Charset) string {
+	if len(board) == 0 {
+		return ""
+	}
+
+	if topology == domain.HexTopology {
+		return hexText(board, showBombs, charset)
+	}
+
+	rowWidth := len(strconv.Itoa(len(board) - 1))
+	cellWidth := len(strconv.Itoa(len(board[0]) - 1))
+
+	var sb strings.Builder
+
+	sb.WriteString(strings.Repeat(" ", rowWidth))
+	for column := range board[0] {
+		sb.WriteString(" ")
+		sb.WriteString(pad(strconv.Itoa(column), cellWidth))
+	}
+	sb.WriteString("\n")
+
+	for row := range board {
+		sb.WriteString(pad(strconv.Itoa(row), rowWidth))
+		writeCells(&sb, board, topology, row, showBombs, charset, cellWidth)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func hexText(board domain.Board, showBombs bool, charset Charset) string {
+	rowWidth := len(strconv.Itoa(len(board) - 1))
+	qWidth := 1
+	for row := range board {
+		q, _ := domain.NewPosition(row, 0).Axial()
+		if width := len(strconv.Itoa(q)); width > qWidth {
+			qWidth = width
+		}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(pad("r", rowWidth))
+	sb.WriteString(" ")
+	sb.WriteString(pad("q", qWidth))
+	sb.WriteString("\n")
+
+	for row := range board {
+		q, r := domain.NewPosition(row, 0).Axial()
+		sb.WriteString(pad(strconv.Itoa(r), rowWidth))
+		sb.WriteString(" ")
+		sb.WriteString(pad(strconv.Itoa(q), qWidth))
+		if row%2 == 1 {
+			sb.WriteString(" ")
+		}
+		writeCells(&sb, board, domain.HexTopology, row, showBombs, charset, 1)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// writeCells writes the symbols of the cells of the given row, each one preceded by a space and aligned within cellWidth
+func writeCells(sb *strings.Builder, board domain.Board, topology domain.Topology, row int, showBombs bool, charset Charset, cellWidth int) {
+	for column := range board[row] {
+		sb.WriteString(" ")
+		sb.WriteString(pad(symbol(board, topology, domain.NewPosition(row, column), showBombs, charset), cellWidth))
+	}
+}
+
+func symbol(board domain.Board, topology domain.Topology, pos domain.Position, showBombs bool, charset Charset) string {
+	switch k, count := classify(board, topology, pos, showBombs); k {
+	case revealed:
+		if count > 0 {
+			return strconv.Itoa(count)
+		}
+
+		return charset.Empty
+	case exploded:
+		return charset.Exploded
+	case flag:
+		return charset.Flag
+	case question:
+		return charset.Question
+	case bomb:
+		return charset.Bomb
+	case disabled:
+		return charset.Disabled
+	default:
+		return charset.Covered
+	}
+}
+
+// pad aligns the text to the right within the given width, counting runes instead of bytes
+func pad(text string, width int) string {
+	if n := len([]rune(text)); n < width {
+		return strings.Repeat(" ", width-n) + text
+	}
+
+	return text
+}
@@ -0,0 +1,72 @@
+package render_test
+
+import (
+	"github.com/matiasvarela/minesweeper-API/internal/core/domain"
+	"github.com/matiasvarela/minesweeper-API/pkg/render"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	wide := domain.NewEmptyBoard(11, 12)
+	wide.Set(domain.NewPosition(10, 11), b)
+	wide.RevealInCascade(domain.StandardTopology, domain.NewPosition(0, 0))
+
+	type args struct {
+		board     domain.Board
+		topology  domain.Topology
+		showBombs bool
+		charset   render.Charset
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		golden string
+	}{
+		{
+			name:   "ongoing game hides the bombs",
+			args:   args{board: mockBoard, topology: domain.StandardTopology, showBombs: false, charset: render.ASCII},
+			golden: "text_ongoing.golden",
+		},
+		{
+			name:   "finished game shows the bombs",
+			args:   args{board: mockBoard, topology: domain.StandardTopology, showBombs: true, charset: render.ASCII},
+			golden: "text_finished.golden",
+		},
+		{
+			name:   "unicode charset",
+			args:   args{board: mockBoard, topology: domain.StandardTopology, showBombs: true, charset: render.Unicode},
+			golden: "text_unicode.golden",
+		},
+		{
+			name:   "cells marked with a question",
+			args:   args{board: mockQuestionedBoard, topology: domain.StandardTopology, showBombs: false, charset: render.ASCII},
+			golden: "text_questions.golden",
+		},
+		{
+			name:   "disabled cells are left blank",
+			args:   args{board: mockMaskedBoard, topology: domain.StandardTopology, showBombs: false, charset: render.ASCII},
+			golden: "text_masked.golden",
+		},
+		{
+			name:   "headers with two digits",
+			args:   args{board: wide, topology: domain.StandardTopology, showBombs: false, charset: render.ASCII},
+			golden: "text_wide.golden",
+		},
+		{
+			name:   "hexagonal board shifts the odd rows and labels them with axial coordinates",
+			args:   args{board: mockHexBoard, topology: domain.HexTopology, showBombs: true, charset: render.ASCII},
+			golden: "text_hex.golden",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := render.Text(tt.args.board, tt.args.topology, tt.args.showBombs, tt.args.charset)
+
+			assertGolden(t, tt.golden, []byte(got))
+		})
+	}
+}
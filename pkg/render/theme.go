@@ -0,0 +1,68 @@
+package render
+
+import "image/color"
+
+// Theme is the set of colors used to draw a board as an image
+type Theme struct {
+	Background color.RGBA
+	Covered    color.RGBA
+	Revealed   color.RGBA
+	Flag       color.RGBA
+	Bomb       color.RGBA
+	Exploded   color.RGBA
+	Numbers    [9]color.RGBA
+}
+
+var (
+	LightTheme = Theme{
+		Background: color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+		Covered:    color.RGBA{R: 0xc0, G: 0xc0, B: 0xc0, A: 0xff},
+		Revealed:   color.RGBA{R: 0xee, G: 0xee, B: 0xee, A: 0xff},
+		Flag:       color.RGBA{R: 0xd0, G: 0x10, B: 0x10, A: 0xff},
+		Bomb:       color.RGBA{R: 0x10, G: 0x10, B: 0x10, A: 0xff},
+		Exploded:   color.RGBA{R: 0xff, G: 0x40, B: 0x40, A: 0xff},
+		Numbers: [9]color.RGBA{
+			{},
+			{R: 0x00, G: 0x00, B: 0xff, A: 0xff},
+			{R: 0x00, G: 0x80, B: 0x00, A: 0xff},
+			{R: 0xff, G: 0x00, B: 0x00, A: 0xff},
+			{R: 0x00, G: 0x00, B: 0x80, A: 0xff},
+			{R: 0x80, G: 0x00, B: 0x00, A: 0xff},
+			{R: 0x00, G: 0x80, B: 0x80, A: 0xff},
+			{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+			{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+		},
+	}
+
+	DarkTheme = Theme{
+		Background: color.RGBA{R: 0x10, G: 0x10, B: 0x14, A: 0xff},
+		Covered:    color.RGBA{R: 0x3a, G: 0x3f, B: 0x4b, A: 0xff},
+		Revealed:   color.RGBA{R: 0x22, G: 0x25, B: 0x2c, A: 0xff},
+		Flag:       color.RGBA{R: 0xff, G: 0x6b, B: 0x6b, A: 0xff},
+		Bomb:       color.RGBA{R: 0xe6, G: 0xe6, B: 0xe6, A: 0xff},
+		Exploded:   color.RGBA{R: 0xb0, G: 0x20, B: 0x20, A: 0xff},
+		Numbers: [9]color.RGBA{
+			{},
+			{R: 0x6c, G: 0xa0, B: 0xff, A: 0xff},
+			{R: 0x6b, G: 0xd4, B: 0x6b, A: 0xff},
+			{R: 0xff, G: 0x7a, B: 0x7a, A: 0xff},
+			{R: 0xb4, G: 0x8c, B: 0xff, A: 0xff},
+			{R: 0xff, G: 0xb0, B: 0x50, A: 0xff},
+			{R: 0x50, G: 0xe0, B: 0xe0, A: 0xff},
+			{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+			{R: 0xa0, G: 0xa0, B: 0xa0, A: 0xff},
+		},
+	}
+)
+
+// ThemeByName returns the theme with the given name; returns false if there is no such theme
+func ThemeByName(name string) (Theme, bool) {
+	switch name {
+	case "", "light":
+		return LightTheme, true
+	case "dark":
+		return DarkTheme, true
+	default:
+		return Theme{}, false
+	}
+}